@@ -1,23 +1,42 @@
 package kakaoapi
 
 import (
+	"context"
 	"encoding/json"
-	"log"
+	"fmt"
+)
+
+// API names of inference APIs
+const (
+	APIKoGPTGeneration  APIName = "kogpt.generation"
+	APIKarloT2I         APIName = "karlo.t2i"
+	APIKarloUpscale     APIName = "karlo.upscale"
+	APIKarloVariations  APIName = "karlo.variations"
+	APIKarloNSFWChecker APIName = "karlo.nsfw_checker"
 )
 
 // GenerateTexts generates texts with given params using KoGPT.
 //
 // https://developers.kakao.com/docs/latest/ko/kogpt/rest-api
 func (c *Client) GenerateTexts(params ParamsTextGeneration) (res ResponseGeneratedTexts, err error) {
+	return c.GenerateTextsWithContext(context.Background(), params)
+}
+
+// GenerateTextsWithContext is the same as GenerateTexts, but with given context.
+func (c *Client) GenerateTextsWithContext(ctx context.Context, params ParamsTextGeneration) (res ResponseGeneratedTexts, err error) {
+	if err = params.Validate(); err != nil {
+		return ResponseGeneratedTexts{}, err
+	}
+
 	var bytes []byte
-	bytes, err = c.post(APIBaseURLKoGPT+"/generation", authTypeKakaoAK, nil, params)
+	bytes, err = c.post(ctx, APIKoGPTGeneration, c.baseURL(ServiceKoGPT)+"/generation", authTypeKakaoAK, nil, params)
 
 	if err == nil {
 		err = json.Unmarshal(bytes, &res)
 		if err == nil {
 			return res, nil
 		} else if c.Verbose {
-			log.Printf("* Failed to decode bytes while generating texts: %s", string(bytes))
+			c.logger.Error("failed to decode bytes while generating texts", "bytes", c.redact(string(bytes)))
 		}
 	}
 
@@ -28,15 +47,41 @@ func (c *Client) GenerateTexts(params ParamsTextGeneration) (res ResponseGenerat
 //
 // https://developers.kakao.com/docs/latest/ko/karlo/rest-api#text-to-image
 func (c *Client) GenerateImages(params ParamsImageGeneration) (res ResponseGeneratedImages, err error) {
+	return c.GenerateImagesWithContext(context.Background(), params)
+}
+
+// GenerateImagesWithContext is the same as GenerateImages, but with given context.
+func (c *Client) GenerateImagesWithContext(ctx context.Context, params ParamsImageGeneration) (res ResponseGeneratedImages, err error) {
+	// (invalid params are rejected before making translation calls)
+	if err = params.validateOptions(); err != nil {
+		return ResponseGeneratedImages{}, err
+	}
+
+	var translated *TranslatedPrompts
+	if params.Prompt, params.NegativePrompt, translated, err = c.translatePrompts(ctx, params.Prompt, params.NegativePrompt); err != nil {
+		return ResponseGeneratedImages{}, err
+	}
+
+	// (prompts' lengths are checked after translation)
+	if err = params.Validate(); err != nil {
+		return ResponseGeneratedImages{}, err
+	}
+
 	var bytes []byte
-	bytes, err = c.post(APIBaseURLKarlo+"/t2i", authTypeKakaoAK, nil, params)
+	if len(params.Seed) > 0 {
+		bytes, err = c.postDeterministic(ctx, APIKarloT2I, c.baseURL(ServiceKarlo)+"/t2i", authTypeKakaoAK, nil, params)
+	} else {
+		bytes, err = c.post(ctx, APIKarloT2I, c.baseURL(ServiceKarlo)+"/t2i", authTypeKakaoAK, nil, params)
+	}
 
 	if err == nil {
 		err = json.Unmarshal(bytes, &res)
 		if err == nil {
+			c.bindImages(res.Images)
+			res.TranslatedPrompts = translated
 			return res, nil
 		} else if c.Verbose {
-			log.Printf("* Failed to decode bytes while generating images: %s", string(bytes))
+			c.logger.Error("failed to decode bytes while generating images", "bytes", c.redact(string(bytes)))
 		}
 	}
 
@@ -47,15 +92,24 @@ func (c *Client) GenerateImages(params ParamsImageGeneration) (res ResponseGener
 //
 // https://developers.kakao.com/docs/latest/ko/karlo/rest-api#upscale
 func (c *Client) UpscaleImages(params ParamsImageUpscale) (res ResponseUpscaledImages, err error) {
+	return c.UpscaleImagesWithContext(context.Background(), params)
+}
+
+// UpscaleImagesWithContext is the same as UpscaleImages, but with given context.
+func (c *Client) UpscaleImagesWithContext(ctx context.Context, params ParamsImageUpscale) (res ResponseUpscaledImages, err error) {
+	if err = params.Validate(); err != nil {
+		return ResponseUpscaledImages{}, err
+	}
+
 	var bytes []byte
-	bytes, err = c.post(APIBaseURLKarlo+"/upscale", authTypeKakaoAK, nil, params)
+	bytes, err = c.postDeterministic(ctx, APIKarloUpscale, c.baseURL(ServiceKarlo)+"/upscale", authTypeKakaoAK, nil, params)
 
 	if err == nil {
 		err = json.Unmarshal(bytes, &res)
 		if err == nil {
 			return res, nil
 		} else if c.Verbose {
-			log.Printf("* Failed to decode bytes while upscaling images: %s", string(bytes))
+			c.logger.Error("failed to decode bytes while upscaling images", "bytes", c.redact(string(bytes)))
 		}
 	}
 
@@ -66,15 +120,41 @@ func (c *Client) UpscaleImages(params ParamsImageUpscale) (res ResponseUpscaledI
 //
 // https://developers.kakao.com/docs/latest/ko/karlo/rest-api#variations
 func (c *Client) VaryImage(params ParamsImageVariation) (res ResponseVariedImages, err error) {
+	return c.VaryImageWithContext(context.Background(), params)
+}
+
+// VaryImageWithContext is the same as VaryImage, but with given context.
+func (c *Client) VaryImageWithContext(ctx context.Context, params ParamsImageVariation) (res ResponseVariedImages, err error) {
+	// (invalid params are rejected before making translation calls)
+	if err = params.validateOptions(); err != nil {
+		return ResponseVariedImages{}, err
+	}
+
+	var translated *TranslatedPrompts
+	if params.Prompt, params.NegativePrompt, translated, err = c.translatePrompts(ctx, params.Prompt, params.NegativePrompt); err != nil {
+		return ResponseVariedImages{}, err
+	}
+
+	// (prompts' lengths are checked after translation)
+	if err = params.Validate(); err != nil {
+		return ResponseVariedImages{}, err
+	}
+
 	var bytes []byte
-	bytes, err = c.post(APIBaseURLKarlo+"/variations", authTypeKakaoAK, nil, params)
+	if len(params.Seed) > 0 {
+		bytes, err = c.postDeterministic(ctx, APIKarloVariations, c.baseURL(ServiceKarlo)+"/variations", authTypeKakaoAK, nil, params)
+	} else {
+		bytes, err = c.post(ctx, APIKarloVariations, c.baseURL(ServiceKarlo)+"/variations", authTypeKakaoAK, nil, params)
+	}
 
 	if err == nil {
 		err = json.Unmarshal(bytes, &res)
 		if err == nil {
+			c.bindImages(res.Images)
+			res.TranslatedPrompts = translated
 			return res, nil
 		} else if c.Verbose {
-			log.Printf("* Failed to decode bytes while varying images: %s", string(bytes))
+			c.logger.Error("failed to decode bytes while varying images", "bytes", c.redact(string(bytes)))
 		}
 	}
 
@@ -85,8 +165,13 @@ func (c *Client) VaryImage(params ParamsImageVariation) (res ResponseVariedImage
 //
 // https://developers.kakao.com/docs/latest/ko/karlo/rest-api#nsfw
 func (c *Client) CheckNSFW(base64EncodedImages []string) (res ResponseNSFWResult, err error) {
+	return c.CheckNSFWWithContext(context.Background(), base64EncodedImages)
+}
+
+// CheckNSFWWithContext is the same as CheckNSFW, but with given context.
+func (c *Client) CheckNSFWWithContext(ctx context.Context, base64EncodedImages []string) (res ResponseNSFWResult, err error) {
 	var bytes []byte
-	bytes, err = c.post(APIBaseURLKarlo+"/nsfw_checker", authTypeKakaoAK, nil, map[string]any{
+	bytes, err = c.postDeterministic(ctx, APIKarloNSFWChecker, c.baseURL(ServiceKarlo)+"/nsfw_checker", authTypeKakaoAK, nil, map[string]any{
 		"images": base64EncodedImages,
 	})
 
@@ -95,9 +180,237 @@ func (c *Client) CheckNSFW(base64EncodedImages []string) (res ResponseNSFWResult
 		if err == nil {
 			return res, nil
 		} else if c.Verbose {
-			log.Printf("* Failed to decode bytes while checking NSFW: %s", string(bytes))
+			c.logger.Error("failed to decode bytes while checking NSFW", "bytes", c.redact(string(bytes)))
 		}
 	}
 
 	return ResponseNSFWResult{}, err
 }
+
+// Karlo parameter ranges
+//
+// https://developers.kakao.com/docs/latest/ko/karlo/rest-api
+const (
+	karloMaxPromptLength       = 256
+	karloMinImageDimension     = 384
+	karloMaxImageDimension     = 2048
+	karloImageDimensionUnit    = 8
+	karloMinImageQuality       = 1
+	karloMaxImageQuality       = 100
+	karloMinSamples            = 1
+	karloMaxSamples            = 8
+	karloMinInferenceSteps     = 10
+	karloMaxInferenceSteps     = 100
+	karloMinGuidanceScale      = 1.0
+	karloMaxGuidanceScale      = 20.0
+	karloMaxSeed               = 4294967295
+	karloDefaultSamples        = 1
+	karloUpscaleScaleDouble    = 2
+	karloUpscaleScaleQuadruple = 4
+)
+
+// checks if given image dimension (if set) is a multiple of 8 within bounds
+func (v *validator) checkImageDimension(field string, value *int) {
+	v.checkIntRange(field, value, karloMinImageDimension, karloMaxImageDimension)
+	if value != nil && *value%karloImageDimensionUnit != 0 {
+		v.fail(field, "%d is not a multiple of %d", *value, karloImageDimensionUnit)
+	}
+}
+
+// checks if given upscale scale (if set) is 2 or 4
+func (v *validator) checkUpscaleScale(field string, value *int) {
+	if value != nil && *value != karloUpscaleScaleDouble && *value != karloUpscaleScaleQuadruple {
+		v.fail(field, "%d is not one of [%d, %d]", *value, karloUpscaleScaleDouble, karloUpscaleScaleQuadruple)
+	}
+}
+
+// checks if given image format (if set) is a known one
+func (v *validator) checkImageFormat(field string, value *ImageFormat) {
+	if value != nil {
+		switch *value {
+		case ImageFormatWEBP, ImageFormatJPEG, ImageFormatPNG:
+		default:
+			v.fail(field, "unknown image format: '%s'", *value)
+		}
+	}
+}
+
+// checks if given return type (if set) is a known one
+func (v *validator) checkReturnType(field string, value *ImageReturnType) {
+	if value != nil {
+		switch *value {
+		case ImageReturnURL, ImageReturnBase64:
+		default:
+			v.fail(field, "unknown return type: '%s'", *value)
+		}
+	}
+}
+
+// checks if given scheduler (if set) is a known one
+func (v *validator) checkScheduler(field string, value *ImageDecodeScheduler) {
+	if value != nil {
+		switch *value {
+		case ImageDecodeSchedulerDDIM, ImageDecodeSchedulerDDPM:
+		default:
+			v.fail(field, "unknown scheduler: '%s'", *value)
+		}
+	}
+}
+
+// checks if given seeds (if set) are in range and their count matches the number of samples
+func (v *validator) checkSeed(field string, seed []int, samples *int) {
+	if seed == nil {
+		return
+	}
+
+	count := karloDefaultSamples
+	if samples != nil {
+		count = *samples
+	}
+	if len(seed) != count {
+		v.fail(field, "count of seeds (%d) does not match samples (%d)", len(seed), count)
+	}
+	for _, s := range seed {
+		if s < 0 || int64(s) > karloMaxSeed {
+			v.fail(field, "%d is out of range [0, %d]", s, int64(karloMaxSeed))
+		}
+	}
+}
+
+// Validate checks if the prompts and options for image generation are in Karlo's documented ranges.
+func (p ParamsImageGeneration) Validate() error {
+	v := &validator{}
+
+	v.checkString("prompt", p.Prompt, true, karloMaxPromptLength)
+	if p.NegativePrompt != nil {
+		v.checkString("negative_prompt", *p.NegativePrompt, false, karloMaxPromptLength)
+	}
+	p.checkOptions(v)
+
+	return v.err()
+}
+
+// validates the parameters other than prompts
+// (for checking them before prompts are translated)
+func (p ParamsImageGeneration) validateOptions() error {
+	v := &validator{}
+	p.checkOptions(v)
+
+	return v.err()
+}
+
+// checks the parameters other than prompts
+func (p ParamsImageGeneration) checkOptions(v *validator) {
+	v.checkImageDimension("width", p.Width)
+	v.checkImageDimension("height", p.Height)
+	v.checkUpscaleScale("scale", p.Scale)
+	v.checkImageFormat("image_format", p.ImageFormat)
+	v.checkIntRange("image_quality", p.ImageQuality, karloMinImageQuality, karloMaxImageQuality)
+	v.checkIntRange("samples", p.Samples, karloMinSamples, karloMaxSamples)
+	v.checkReturnType("return_type", p.ReturnType)
+	v.checkIntRange("prior_num_inference_steps", p.PriorNumInferenceSteps, karloMinInferenceSteps, karloMaxInferenceSteps)
+	v.checkFloatRange("prior_guidance_scale", p.PriorGuidanceScale, karloMinGuidanceScale, karloMaxGuidanceScale)
+	v.checkIntRange("num_inference_steps", p.NumInferenceSteps, karloMinInferenceSteps, karloMaxInferenceSteps)
+	v.checkFloatRange("guidance_scale", p.GuidanceScale, karloMinGuidanceScale, karloMaxGuidanceScale)
+	v.checkScheduler("scheduler", p.Scheduler)
+	v.checkSeed("seed", p.Seed, p.Samples)
+}
+
+// Validate checks if the parameters have an image to vary,
+// and the prompts and options are in Karlo's documented ranges.
+func (p ParamsImageVariation) Validate() error {
+	v := &validator{}
+
+	v.checkString("prompt", p.Prompt, false, karloMaxPromptLength)
+	if p.NegativePrompt != nil {
+		v.checkString("negative_prompt", *p.NegativePrompt, false, karloMaxPromptLength)
+	}
+	p.checkOptions(v)
+
+	return v.err()
+}
+
+// validates the parameters other than prompts
+// (for checking them before prompts are translated)
+func (p ParamsImageVariation) validateOptions() error {
+	v := &validator{}
+	p.checkOptions(v)
+
+	return v.err()
+}
+
+// checks the parameters other than prompts
+func (p ParamsImageVariation) checkOptions(v *validator) {
+	v.checkString("image", p.Image, true, 0)
+	v.checkImageDimension("width", p.Width)
+	v.checkImageDimension("height", p.Height)
+	v.checkUpscaleScale("scale", p.Scale)
+	v.checkImageFormat("image_format", p.ImageFormat)
+	v.checkIntRange("image_quality", p.ImageQuality, karloMinImageQuality, karloMaxImageQuality)
+	v.checkIntRange("samples", p.Samples, karloMinSamples, karloMaxSamples)
+	v.checkReturnType("return_type", p.ReturnType)
+	v.checkIntRange("num_inference_steps", p.NumInferenceSteps, karloMinInferenceSteps, karloMaxInferenceSteps)
+	v.checkFloatRange("guidance_scale", p.GuidanceScale, karloMinGuidanceScale, karloMaxGuidanceScale)
+	v.checkScheduler("scheduler", p.Scheduler)
+	v.checkSeed("seed", p.Seed, p.Samples)
+}
+
+// Validate checks if the parameters have images to upscale,
+// and a valid scale, image format, quality, and return type.
+func (p ParamsImageUpscale) Validate() error {
+	v := &validator{}
+
+	if len(p.Images) <= 0 {
+		v.fail("images", "required")
+	}
+	for i, image := range p.Images {
+		v.checkString(fmt.Sprintf("images[%d]", i), image, true, 0)
+	}
+	v.checkUpscaleScale("scale", p.Scale)
+	v.checkImageFormat("image_format", p.ImageFormat)
+	v.checkIntRange("image_quality", p.ImageQuality, karloMinImageQuality, karloMaxImageQuality)
+	v.checkReturnType("return_type", p.ReturnType)
+
+	return v.err()
+}
+
+// KoGPT parameter ranges
+//
+// https://developers.kakao.com/docs/latest/ko/kogpt/rest-api
+const (
+	kogptMaxContextTokens = 2048
+	kogptMinMaxTokens     = 1
+	kogptMaxTemperature   = 1.0
+	kogptMaxTopP          = 1.0
+	kogptMinN             = 1
+	kogptMaxN             = 16
+)
+
+// Validate checks if the parameters are in KoGPT's documented ranges,
+// and if the estimated tokens of prompt plus `max_tokens` fit in KoGPT's context.
+func (p ParamsTextGeneration) Validate() error {
+	v := &validator{}
+
+	v.checkString("prompt", p.Prompt, true, 0)
+	if p.MaxTokens < kogptMinMaxTokens || p.MaxTokens > kogptMaxContextTokens {
+		v.fail("max_tokens", "%d is out of range [%d, %d]", p.MaxTokens, kogptMinMaxTokens, kogptMaxContextTokens)
+	}
+	if p.Temperature != nil && (*p.Temperature <= 0 || *p.Temperature > kogptMaxTemperature) {
+		v.fail("temperature", "%g is out of range (0, %g]", *p.Temperature, kogptMaxTemperature)
+	}
+	if p.TopP != nil && (*p.TopP <= 0 || *p.TopP > kogptMaxTopP) {
+		v.fail("top_p", "%g is out of range (0, %g]", *p.TopP, kogptMaxTopP)
+	}
+	v.checkIntRange("n", p.N, kogptMinN, kogptMaxN)
+
+	if tokens := p.EstimatedTokens(); tokens > kogptMaxContextTokens {
+		v.fail("prompt", "estimated tokens of prompt plus max_tokens (%d) exceed %d", tokens, kogptMaxContextTokens)
+	}
+
+	return v.err()
+}
+
+// EstimatedTokens returns the estimated number of tokens of the prompt plus `max_tokens`.
+func (p ParamsTextGeneration) EstimatedTokens() int {
+	return EstimateTokens(p.Prompt) + p.MaxTokens
+}
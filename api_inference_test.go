@@ -1,9 +1,17 @@
 package kakaoapi
 
 import (
+	"context"
+	"errors"
+	"io/fs"
 	"log"
+	"net/http"
 	"os"
+	"path/filepath"
 	"testing"
+	"time"
+
+	"github.com/meinside/kakao-api-go/kakaoapitest"
 )
 
 func isVerbose() bool {
@@ -12,17 +20,51 @@ func isVerbose() bool {
 	return verbose == "true"
 }
 
-func TestKoGPT(t *testing.T) {
+// returns a client which replays (or records, with `KAKAO_RECORD=1`) interactions with the cassette of given name
+//
+// If the cassette is not recorded yet, the client calls the real APIs with `KAKAO_API_KEY`,
+// or the test is skipped without it.
+func newCassetteClient(t *testing.T, name string) *Client {
 	_apiKey := os.Getenv("KAKAO_API_KEY")
-	_verbose := isVerbose()
+	_mode := kakaoapitest.CassetteModeFromEnv()
 
-	client := NewClient(_apiKey)
-	client.Verbose = _verbose
+	if _mode == kakaoapitest.CassetteModeRecord && len(_apiKey) <= 0 {
+		t.Fatalf("environment variable `KAKAO_API_KEY` is needed for recording")
+	}
 
-	if len(_apiKey) <= 0 {
-		t.Errorf("environment variable `KAKAO_API_KEY` is needed")
+	path := filepath.Join("testdata", "cassettes", name+".json")
+	if _mode == kakaoapitest.CassetteModeReplay {
+		if _, err := os.Stat(path); errors.Is(err, fs.ErrNotExist) {
+			if len(_apiKey) <= 0 {
+				t.Skipf("no recorded cassette at %s, and no `KAKAO_API_KEY` for calling the real APIs", path)
+			}
+			return NewClient(_apiKey, WithVerbose(isVerbose()))
+		}
 	}
 
+	cassette, err := kakaoapitest.NewCassette(path, _mode, nil)
+	if err != nil {
+		t.Fatalf("failed to load cassette: %s", err)
+	}
+	t.Cleanup(func() {
+		if err := cassette.Save(); err != nil {
+			t.Errorf("failed to save cassette: %s", err)
+		}
+	})
+
+	return NewClient(_apiKey,
+		WithHTTPClient(&http.Client{Transport: cassette}),
+		WithVerbose(isVerbose()),
+	)
+}
+
+func TestKoGPT(t *testing.T) {
+	testKoGPT(t, newCassetteClient(t, "kogpt"))
+}
+
+func testKoGPT(t *testing.T, client *Client) {
+	_verbose := isVerbose()
+
 	params := NewParamsTextGeneration("오늘 아침 하늘은 곧 비가 올 것 같아서", 120).
 		SetN(2)
 
@@ -40,15 +82,11 @@ func TestKoGPT(t *testing.T) {
 }
 
 func TestKarlo(t *testing.T) {
-	_apiKey := os.Getenv("KAKAO_API_KEY")
-	_verbose := isVerbose()
-
-	client := NewClient(_apiKey)
-	client.Verbose = _verbose
+	testKarlo(t, newCassetteClient(t, "karlo"))
+}
 
-	if len(_apiKey) <= 0 {
-		t.Errorf("environment variable `KAKAO_API_KEY` is needed")
-	}
+func testKarlo(t *testing.T, client *Client) {
+	_verbose := isVerbose()
 
 	// generation
 	paramsGeneration := NewParamsImageGeneration("A cat with white fur").
@@ -109,3 +147,101 @@ func TestKarlo(t *testing.T) {
 		}
 	}
 }
+
+// returns a client for the fake server
+func newFakeClient(server *kakaoapitest.Server, opts ...ClientOption) *Client {
+	return NewClient(server.APIKey, append([]ClientOption{
+		WithBaseURL(ServiceKoGPT, server.KoGPTURL()),
+		WithBaseURL(ServiceKarlo, server.KarloURL()),
+		WithVerbose(isVerbose()),
+	}, opts...)...)
+}
+
+func TestKoGPTOffline(t *testing.T) {
+	server := kakaoapitest.NewServer()
+	defer server.Close()
+
+	client := newFakeClient(server)
+
+	params := NewParamsTextGeneration("오늘 아침 하늘은 곧 비가 올 것 같아서", 120).
+		SetN(2)
+
+	if generated, err := client.GenerateTexts(params); err != nil {
+		t.Errorf("failed to generate texts: %s", err)
+	} else if len(generated.Generations) != 2 {
+		t.Errorf("count of generated texts is different from request: %d", len(generated.Generations))
+	}
+
+	server.AssertCalled(t, kakaoapitest.EndpointKoGPTGeneration, 1)
+	server.AssertParam(t, kakaoapitest.EndpointKoGPTGeneration, "max_tokens", 120)
+	server.AssertHeader(t, kakaoapitest.EndpointKoGPTGeneration, "Authorization", "KakaoAK "+server.APIKey)
+
+	// error injection
+	server.FailNext(kakaoapitest.EndpointKoGPTGeneration, 1, http.StatusBadRequest, ErrorCodeInappropriateInput, "inappropriate input")
+	if _, err := client.GenerateTexts(params); !IsNSFWRejected(err) {
+		t.Errorf("should fail with injected error: %v", err)
+	}
+
+	// latency injection
+	server.SetLatency(kakaoapitest.EndpointKoGPTGeneration, time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	if _, err := client.GenerateTextsWithContext(ctx, params); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("should fail with deadline exceeded: %v", err)
+	}
+}
+
+func TestKarloOffline(t *testing.T) {
+	server := kakaoapitest.NewServer()
+	defer server.Close()
+
+	client := newFakeClient(server, WithRetryPolicy(testRetryPolicy(3)))
+	ctx := context.Background()
+
+	// generation (retried after injected errors)
+	server.FailNext(kakaoapitest.EndpointKarloT2I, 2, http.StatusServiceUnavailable, ErrorCodeInternal, "internal error")
+	paramsGeneration := NewParamsImageGeneration("A cat with white fur").
+		SetReturnType(ImageReturnURL).
+		SetSamples(2).
+		SetSeed([]int{1, 2})
+	if generated, err := client.GenerateImages(paramsGeneration); err != nil {
+		t.Errorf("failed to generate images: %s", err)
+	} else if len(generated.Images) != 2 {
+		t.Errorf("count of generated images is different from request: %d", len(generated.Images))
+	} else if _, err := generated.Images[0].DecodeImage(ctx); err != nil {
+		t.Errorf("failed to decode generated image: %s", err)
+	}
+	server.AssertCalled(t, kakaoapitest.EndpointKarloT2I, 3)
+
+	image, _, err := EncodeImageFile(sampleImagePath)
+	if err != nil {
+		t.Fatalf("failed to encode sample image file: %s", err)
+	}
+
+	// upscale
+	if scaledUp, err := client.UpscaleImages(NewParamsImageUpscale([]string{image})); err != nil {
+		t.Errorf("failed to upscale image: %s", err)
+	} else if len(scaledUp.Images) != 1 {
+		t.Errorf("count of upscaled images is different from request: %d", len(scaledUp.Images))
+	}
+
+	// variation
+	if varied, err := client.VaryImage(NewParamsImageVariation(image, "modern").SetSamples(3)); err != nil {
+		t.Errorf("failed to vary image: %s", err)
+	} else if len(varied.Images) != 3 {
+		t.Errorf("count of varied images is different from request: %d", len(varied.Images))
+	}
+	server.AssertParam(t, kakaoapitest.EndpointKarloVariations, "image", image)
+
+	// check NSFW
+	if nsfw, err := client.CheckNSFW([]string{image, image}); err != nil {
+		t.Errorf("failed to check NSFW: %s", err)
+	} else if len(nsfw.Results) != 2 {
+		t.Errorf("count of NSFW results is different from request: %d", len(nsfw.Results))
+	}
+
+	// invalid API key
+	if _, err := NewClient("invalid-api-key", WithBaseURL(ServiceKarlo, server.KarloURL())).CheckNSFW([]string{image}); !IsInvalidAPIKey(err) {
+		t.Errorf("should fail with invalid API key: %v", err)
+	}
+}
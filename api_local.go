@@ -0,0 +1,280 @@
+package kakaoapi
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+)
+
+// API names of Local APIs
+const (
+	APILocalAddress        APIName = "local.address"
+	APILocalCoord2Address  APIName = "local.coord2address"
+	APILocalCoord2Region   APIName = "local.coord2regioncode"
+	APILocalTransformCoord APIName = "local.transcoord"
+	APILocalKeyword        APIName = "local.keyword"
+	APILocalCategory       APIName = "local.category"
+)
+
+// SearchAddress searches addresses with given params.
+//
+// https://developers.kakao.com/docs/latest/ko/local/dev-guide#address-coord
+func (c *Client) SearchAddress(ctx context.Context, params ParamsAddressSearch) (res ResponseAddresses, err error) {
+	if err = params.Validate(); err != nil {
+		return ResponseAddresses{}, err
+	}
+
+	query := map[string]any{
+		"query": params.Query,
+	}
+	if params.AnalyzeType != nil {
+		query["analyze_type"] = *params.AnalyzeType
+	}
+	if params.Page != nil {
+		query["page"] = *params.Page
+	}
+	if params.Size != nil {
+		query["size"] = *params.Size
+	}
+
+	err = c.getLocal(ctx, APILocalAddress, "/v2/local/search/address.json", query, &res, "searching address")
+
+	return res, err
+}
+
+// SearchAddressIterator returns an iterator over all address search results, starting from the page of given params.
+func (c *Client) SearchAddressIterator(params ParamsAddressSearch) *Iterator[AddressDocument] {
+	return newIterator(func(ctx context.Context) ([]AddressDocument, bool, error) {
+		res, err := c.SearchAddress(ctx, params)
+		if err != nil {
+			return nil, false, err
+		}
+
+		page, hasNext := nextPage(params.Page, res.Meta, localMaxPage)
+		params = params.SetPage(page)
+
+		return res.Documents, hasNext, nil
+	})
+}
+
+// CoordToAddress returns the addresses of given coordinate.
+//
+// `inputCoord` is the coordinate system of x and y (WGS84 if empty).
+//
+// https://developers.kakao.com/docs/latest/ko/local/dev-guide#coord-to-address
+func (c *Client) CoordToAddress(ctx context.Context, x, y float64, inputCoord CoordSystem) (res ResponseCoordAddresses, err error) {
+	err = c.getLocal(ctx, APILocalCoord2Address, "/v2/local/geo/coord2address.json", coordQuery(x, y, inputCoord, ""), &res, "converting coord to address")
+
+	return res, err
+}
+
+// CoordToRegionCode returns the administrative and legal regions of given coordinate.
+//
+// `inputCoord` and `outputCoord` are the coordinate systems of given and returned coordinates (WGS84 if empty).
+//
+// https://developers.kakao.com/docs/latest/ko/local/dev-guide#coord-to-district
+func (c *Client) CoordToRegionCode(ctx context.Context, x, y float64, inputCoord, outputCoord CoordSystem) (res ResponseRegionCodes, err error) {
+	err = c.getLocal(ctx, APILocalCoord2Region, "/v2/local/geo/coord2regioncode.json", coordQuery(x, y, inputCoord, outputCoord), &res, "converting coord to region code")
+
+	return res, err
+}
+
+// TransformCoord transforms given coordinate from `inputCoord` to `outputCoord` system.
+//
+// https://developers.kakao.com/docs/latest/ko/local/dev-guide#trans-coord
+func (c *Client) TransformCoord(ctx context.Context, x, y float64, inputCoord, outputCoord CoordSystem) (res ResponseTransformedCoords, err error) {
+	if len(outputCoord) <= 0 {
+		return ResponseTransformedCoords{}, &ValidationError{Field: "output_coord", Message: "required"}
+	}
+
+	err = c.getLocal(ctx, APILocalTransformCoord, "/v2/local/geo/transcoord.json", coordQuery(x, y, inputCoord, outputCoord), &res, "transforming coord")
+
+	return res, err
+}
+
+// SearchKeyword searches places with the query of given params.
+//
+// https://developers.kakao.com/docs/latest/ko/local/dev-guide#search-by-keyword
+func (c *Client) SearchKeyword(ctx context.Context, params ParamsPlaceSearch) (res ResponsePlaces, err error) {
+	if err = params.Validate(); err != nil {
+		return ResponsePlaces{}, err
+	}
+	if len(params.Query) <= 0 {
+		return ResponsePlaces{}, &ValidationError{Field: "query", Message: "required"}
+	}
+
+	err = c.getLocal(ctx, APILocalKeyword, "/v2/local/search/keyword.json", placeQuery(params), &res, "searching keyword")
+
+	return res, err
+}
+
+// SearchKeywordIterator returns an iterator over all keyword search results, starting from the page of given params.
+func (c *Client) SearchKeywordIterator(params ParamsPlaceSearch) *Iterator[Place] {
+	return c.placeIterator(params, c.SearchKeyword)
+}
+
+// SearchCategory searches places with the category group code of given params.
+//
+// https://developers.kakao.com/docs/latest/ko/local/dev-guide#search-by-category
+func (c *Client) SearchCategory(ctx context.Context, params ParamsPlaceSearch) (res ResponsePlaces, err error) {
+	if params.CategoryGroupCode == nil {
+		return ResponsePlaces{}, &ValidationError{Field: "category_group_code", Message: "required"}
+	}
+	params.Query = "" // (not used for category search)
+	if err = params.Validate(); err != nil {
+		return ResponsePlaces{}, err
+	}
+
+	err = c.getLocal(ctx, APILocalCategory, "/v2/local/search/category.json", placeQuery(params), &res, "searching category")
+
+	return res, err
+}
+
+// SearchCategoryIterator returns an iterator over all category search results, starting from the page of given params.
+func (c *Client) SearchCategoryIterator(params ParamsPlaceSearch) *Iterator[Place] {
+	return c.placeIterator(params, c.SearchCategory)
+}
+
+// returns an iterator over the pages of given place search
+func (c *Client) placeIterator(params ParamsPlaceSearch, search func(context.Context, ParamsPlaceSearch) (ResponsePlaces, error)) *Iterator[Place] {
+	return newIterator(func(ctx context.Context) ([]Place, bool, error) {
+		res, err := search(ctx, params)
+		if err != nil {
+			return nil, false, err
+		}
+
+		page, hasNext := nextPage(params.Page, res.Meta.Meta, localMaxPage)
+		params = params.SetPage(page)
+
+		return res.Documents, hasNext, nil
+	})
+}
+
+// fetches given Local API path with the REST API key, and decodes its response into `res`
+func (c *Client) getLocal(ctx context.Context, api APIName, path string, query map[string]any, res any, action string) (err error) {
+	var bytes []byte
+	bytes, err = c.get(ctx, api, c.baseURL(ServiceDapi)+path, authTypeKakaoAK, nil, query)
+
+	if err == nil {
+		err = json.Unmarshal(bytes, res)
+		if err != nil && c.Verbose {
+			c.logger.Error("failed to decode bytes while "+action, "bytes", c.redact(string(bytes)))
+		}
+	}
+
+	return err
+}
+
+// returns the query for coordinate conversions
+func coordQuery(x, y float64, inputCoord, outputCoord CoordSystem) map[string]any {
+	query := map[string]any{
+		"x": formatFloat(x),
+		"y": formatFloat(y),
+	}
+	if len(inputCoord) > 0 {
+		query["input_coord"] = string(inputCoord)
+	}
+	if len(outputCoord) > 0 {
+		query["output_coord"] = string(outputCoord)
+	}
+
+	return query
+}
+
+// returns the query for place searches
+func placeQuery(params ParamsPlaceSearch) map[string]any {
+	query := map[string]any{}
+	if len(params.Query) > 0 {
+		query["query"] = params.Query
+	}
+	if params.CategoryGroupCode != nil {
+		query["category_group_code"] = string(*params.CategoryGroupCode)
+	}
+	if params.X != nil && params.Y != nil {
+		query["x"] = formatFloat(*params.X)
+		query["y"] = formatFloat(*params.Y)
+	}
+	if params.Radius != nil {
+		query["radius"] = *params.Radius
+	}
+	if params.Rect != nil {
+		query["rect"] = *params.Rect
+	}
+	if params.Page != nil {
+		query["page"] = *params.Page
+	}
+	if params.Size != nil {
+		query["size"] = *params.Size
+	}
+	if params.Sort != nil {
+		query["sort"] = *params.Sort
+	}
+
+	return query
+}
+
+// returns the page after given one (1 if nil), and whether it should be fetched
+func nextPage(page *int, meta Meta, maxPage int) (next int, hasNext bool) {
+	next = 1
+	if page != nil {
+		next = *page
+	}
+	next++
+
+	return next, !meta.IsEnd && next <= maxPage
+}
+
+// formats given float without exponents (eg. for coordinates)
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+// Local API parameter ranges
+//
+// https://developers.kakao.com/docs/latest/ko/local/dev-guide
+const (
+	localMaxPage        = 45
+	localMaxAddressSize = 30
+	localMaxPlaceSize   = 15
+	localMaxRadius      = 20000
+)
+
+// Validate checks if the parameters have a query, and the page and size are in the documented ranges.
+func (p ParamsAddressSearch) Validate() error {
+	v := &validator{}
+
+	v.checkString("query", p.Query, true, 0)
+	if p.AnalyzeType != nil && *p.AnalyzeType != "similar" && *p.AnalyzeType != "exact" {
+		v.fail("analyze_type", "'%s' is not one of similar, exact", *p.AnalyzeType)
+	}
+	v.checkIntRange("page", p.Page, 1, localMaxPage)
+	v.checkIntRange("size", p.Size, 1, localMaxAddressSize)
+
+	return v.err()
+}
+
+// Validate checks if the parameters have a query or category group code,
+// and the other values are in the documented ranges.
+func (p ParamsPlaceSearch) Validate() error {
+	v := &validator{}
+
+	if len(p.Query) <= 0 {
+		// category search
+		if p.CategoryGroupCode == nil || len(*p.CategoryGroupCode) <= 0 {
+			v.fail("query", "one of query and category_group_code is required")
+		} else if p.Rect == nil && (p.X == nil || p.Y == nil || p.Radius == nil) {
+			v.fail("rect", "one of rect and x, y, radius is required for category search")
+		}
+	}
+	if (p.X == nil) != (p.Y == nil) {
+		v.fail("x", "x and y should be set together")
+	}
+	v.checkIntRange("radius", p.Radius, 0, localMaxRadius)
+	v.checkIntRange("page", p.Page, 1, localMaxPage)
+	v.checkIntRange("size", p.Size, 1, localMaxPlaceSize)
+	if p.Sort != nil && *p.Sort != "accuracy" && *p.Sort != "distance" {
+		v.fail("sort", "'%s' is not one of accuracy, distance", *p.Sort)
+	}
+
+	return v.err()
+}
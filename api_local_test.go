@@ -0,0 +1,98 @@
+package kakaoapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+)
+
+func TestLocalAPIs(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if authorization := r.Header.Get("Authorization"); authorization != "KakaoAK test-api-key" {
+			t.Errorf("unexpected authorization: %s", authorization)
+		}
+
+		query := r.URL.Query()
+		switch r.URL.Path {
+		case "/v2/local/search/address.json":
+			page, _ := strconv.Atoi(query.Get("page"))
+			if page <= 0 {
+				page = 1
+			}
+			fmt.Fprintf(w, `{"meta":{"total_count":3,"pageable_count":3,"is_end":%t},"documents":[{"address_name":"address %d","address_type":"ROAD_ADDR","x":"127.1","y":"37.4","road_address":{"address_name":"road %d","zone_no":"13529"}}]}`, page >= 3, page, page)
+		case "/v2/local/geo/coord2address.json":
+			if query.Get("x") != "127.423084873712" || query.Get("y") != "37.0789561558879" || query.Get("input_coord") != "WGS84" {
+				t.Errorf("unexpected query: %s", r.URL.RawQuery)
+			}
+			w.Write([]byte(`{"meta":{"total_count":1},"documents":[{"road_address":null,"address":{"address_name":"경기 안성시 죽산면 죽산리 343-1","region_1depth_name":"경기","mountain_yn":"N","main_address_no":"343","sub_address_no":"1"}}]}`))
+		case "/v2/local/geo/coord2regioncode.json":
+			w.Write([]byte(`{"meta":{"total_count":2},"documents":[{"region_type":"B","code":"4155034022","address_name":"경기도 안성시 죽산면 죽산리","x":127.4232,"y":37.0789},{"region_type":"H","code":"4155034000","address_name":"경기도 안성시 죽산면","x":127.4232,"y":37.0789}]}`))
+		case "/v2/local/geo/transcoord.json":
+			if query.Get("x") != "1000000" || query.Get("output_coord") != "WGS84" {
+				t.Errorf("unexpected query: %s", r.URL.RawQuery)
+			}
+			w.Write([]byte(`{"meta":{"total_count":1},"documents":[{"x":127.1,"y":37.4}]}`))
+		case "/v2/local/search/keyword.json":
+			if query.Get("query") != "카카오프렌즈" || query.Get("radius") != "1000" || query.Get("sort") != "distance" {
+				t.Errorf("unexpected query: %s", r.URL.RawQuery)
+			}
+			w.Write([]byte(`{"meta":{"total_count":1,"pageable_count":1,"is_end":true,"same_name":{"region":[],"keyword":"카카오프렌즈","selected_region":""}},"documents":[{"id":"26338954","place_name":"카카오프렌즈 가로수길 플래그십스토어","category_group_code":"","x":"127.02","y":"37.52","distance":"418"}]}`))
+		case "/v2/local/search/category.json":
+			if query.Get("category_group_code") != "PM9" || query.Get("rect") != "127.1,37.4,127.2,37.5" {
+				t.Errorf("unexpected query: %s", r.URL.RawQuery)
+			}
+			w.Write([]byte(`{"meta":{"total_count":1,"pageable_count":1,"is_end":true},"documents":[{"id":"1","place_name":"약국","category_group_code":"PM9","x":"127.15","y":"37.45"}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(ServiceDapi, server.URL))
+	ctx := context.Background()
+
+	if addresses, err := client.SearchAddressIterator(NewParamsAddressSearch("판교역로 166")).All(ctx); err != nil {
+		t.Errorf("failed to iterate addresses: %s", err)
+	} else if len(addresses) != 3 || addresses[2].AddressName != "address 3" || addresses[0].RoadAddress.ZoneNo != "13529" {
+		t.Errorf("unexpected addresses: %+v", addresses)
+	}
+
+	if addresses, err := client.CoordToAddress(ctx, 127.423084873712, 37.0789561558879, CoordSystemWGS84); err != nil {
+		t.Errorf("failed to convert coord to address: %s", err)
+	} else if len(addresses.Documents) != 1 || addresses.Documents[0].RoadAddress != nil || addresses.Documents[0].Address.MainAddressNo != "343" {
+		t.Errorf("unexpected addresses: %+v", addresses)
+	}
+
+	if regions, err := client.CoordToRegionCode(ctx, 127.4232, 37.0789, "", ""); err != nil {
+		t.Errorf("failed to convert coord to region code: %s", err)
+	} else if len(regions.Documents) != 2 || regions.Documents[1].RegionType != "H" {
+		t.Errorf("unexpected regions: %+v", regions)
+	}
+
+	if _, err := client.TransformCoord(ctx, 1000000, 500000, CoordSystemWCONGNAMUL, ""); err == nil {
+		t.Errorf("should have failed without output coord")
+	}
+	if coords, err := client.TransformCoord(ctx, 1000000, 500000, CoordSystemWCONGNAMUL, CoordSystemWGS84); err != nil {
+		t.Errorf("failed to transform coord: %s", err)
+	} else if len(coords.Documents) != 1 || coords.Documents[0].X != 127.1 {
+		t.Errorf("unexpected coords: %+v", coords)
+	}
+
+	if places, err := client.SearchKeyword(ctx, NewParamsKeywordSearch("카카오프렌즈").SetCenter(127.06, 37.51).SetRadius(1000).SetSort("distance")); err != nil {
+		t.Errorf("failed to search keyword: %s", err)
+	} else if len(places.Documents) != 1 || places.Documents[0].Distance != "418" || places.Meta.SameName == nil || !places.Meta.IsEnd {
+		t.Errorf("unexpected places: %+v", places)
+	}
+
+	if _, err := client.SearchCategory(ctx, NewParamsCategorySearch(CategoryGroupPharmacy)); err == nil {
+		t.Errorf("should have failed without rect or center")
+	}
+	if places, err := client.SearchCategoryIterator(NewParamsCategorySearch(CategoryGroupPharmacy).SetRect(127.1, 37.4, 127.2, 37.5)).All(ctx); err != nil {
+		t.Errorf("failed to search category: %s", err)
+	} else if len(places) != 1 || places[0].CategoryGroupCode != "PM9" {
+		t.Errorf("unexpected places: %+v", places)
+	}
+}
@@ -0,0 +1,322 @@
+package kakaoapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// API names of KakaoTalk message APIs
+const (
+	APIMessageMeDefault      APIName = "message.me.default"
+	APIMessageMeCustom       APIName = "message.me.custom"
+	APIMessageMeScrap        APIName = "message.me.scrap"
+	APIMessageFriendsDefault APIName = "message.friends.default"
+	APIMessageFriendsCustom  APIName = "message.friends.custom"
+	APIMessageFriendsScrap   APIName = "message.friends.scrap"
+)
+
+// SendToMe sends a message with given default template to the current user.
+//
+// https://developers.kakao.com/docs/latest/ko/message/rest-api#default-template-msg-me
+func (c *Client) SendToMe(ctx context.Context, template MessageTemplate) (res ResponseMessageToMe, err error) {
+	var form url.Values
+	if form, err = templateForm(template); err != nil {
+		return ResponseMessageToMe{}, err
+	}
+
+	err = c.sendMessage(ctx, APIMessageMeDefault, "/v2/api/talk/memo/default/send", form, &res)
+
+	return res, err
+}
+
+// SendCustomToMe sends a message with given custom template to the current user.
+//
+// https://developers.kakao.com/docs/latest/ko/message/rest-api#custom-template-msg-me
+func (c *Client) SendCustomToMe(ctx context.Context, templateID int64, templateArgs map[string]string) (res ResponseMessageToMe, err error) {
+	var form url.Values
+	if form, err = customForm(templateID, templateArgs); err != nil {
+		return ResponseMessageToMe{}, err
+	}
+
+	err = c.sendMessage(ctx, APIMessageMeCustom, "/v2/api/talk/memo/send", form, &res)
+
+	return res, err
+}
+
+// SendScrapToMe sends a message scrapped from given URL to the current user.
+//
+// `templateID` is optional (0 for none).
+//
+// https://developers.kakao.com/docs/latest/ko/message/rest-api#send-scrap-message
+func (c *Client) SendScrapToMe(ctx context.Context, requestURL string, templateID int64, templateArgs map[string]string) (res ResponseMessageToMe, err error) {
+	var form url.Values
+	if form, err = scrapForm(requestURL, templateID, templateArgs); err != nil {
+		return ResponseMessageToMe{}, err
+	}
+
+	err = c.sendMessage(ctx, APIMessageMeScrap, "/v2/api/talk/memo/scrap/send", form, &res)
+
+	return res, err
+}
+
+// SendToFriends sends a message with given default template to friends with given UUIDs (up to 5).
+//
+// https://developers.kakao.com/docs/latest/ko/message/rest-api#default-template-msg-friend
+func (c *Client) SendToFriends(ctx context.Context, receiverUUIDs []string, template MessageTemplate) (res ResponseMessageToFriends, err error) {
+	if err = validateReceivers(receiverUUIDs); err != nil {
+		return ResponseMessageToFriends{}, err
+	}
+
+	var form url.Values
+	if form, err = templateForm(template); err != nil {
+		return ResponseMessageToFriends{}, err
+	}
+	form.Set("receiver_uuids", jsonString(receiverUUIDs))
+
+	err = c.sendMessage(ctx, APIMessageFriendsDefault, "/v1/api/talk/friends/message/default/send", form, &res)
+
+	return res, err
+}
+
+// SendCustomToFriends sends a message with given custom template to friends with given UUIDs (up to 5).
+//
+// https://developers.kakao.com/docs/latest/ko/message/rest-api#custom-template-msg-friend
+func (c *Client) SendCustomToFriends(ctx context.Context, receiverUUIDs []string, templateID int64, templateArgs map[string]string) (res ResponseMessageToFriends, err error) {
+	if err = validateReceivers(receiverUUIDs); err != nil {
+		return ResponseMessageToFriends{}, err
+	}
+
+	var form url.Values
+	if form, err = customForm(templateID, templateArgs); err != nil {
+		return ResponseMessageToFriends{}, err
+	}
+	form.Set("receiver_uuids", jsonString(receiverUUIDs))
+
+	err = c.sendMessage(ctx, APIMessageFriendsCustom, "/v1/api/talk/friends/message/send", form, &res)
+
+	return res, err
+}
+
+// SendScrapToFriends sends a message scrapped from given URL to friends with given UUIDs (up to 5).
+//
+// `templateID` is optional (0 for none).
+//
+// https://developers.kakao.com/docs/latest/ko/message/rest-api#send-scrap-message-friend
+func (c *Client) SendScrapToFriends(ctx context.Context, receiverUUIDs []string, requestURL string, templateID int64, templateArgs map[string]string) (res ResponseMessageToFriends, err error) {
+	if err = validateReceivers(receiverUUIDs); err != nil {
+		return ResponseMessageToFriends{}, err
+	}
+
+	var form url.Values
+	if form, err = scrapForm(requestURL, templateID, templateArgs); err != nil {
+		return ResponseMessageToFriends{}, err
+	}
+	form.Set("receiver_uuids", jsonString(receiverUUIDs))
+
+	err = c.sendMessage(ctx, APIMessageFriendsScrap, "/v1/api/talk/friends/message/scrap/send", form, &res)
+
+	return res, err
+}
+
+// posts given form to the message API, and decodes its response into `res`
+func (c *Client) sendMessage(ctx context.Context, api APIName, path string, form url.Values, res any) (err error) {
+	var bytes []byte
+	bytes, err = c.post(ctx, api, c.baseURL(ServiceKakaoAPI)+path, authTypeBearer, nil, form)
+
+	if err == nil {
+		err = json.Unmarshal(bytes, res)
+		if err != nil && c.Verbose {
+			c.logger.Error("failed to decode bytes while sending message", "bytes", c.redact(string(bytes)))
+		}
+	}
+
+	return err
+}
+
+// returns the form with given template as `template_object`, after validating it
+func templateForm(template MessageTemplate) (url.Values, error) {
+	if template == nil {
+		return nil, &ValidationError{Field: "template_object", Message: "required"}
+	}
+	if err := template.Validate(); err != nil {
+		return nil, err
+	}
+
+	// add `object_type` to the marshalled template
+	var object map[string]json.RawMessage
+	if bytes, err := json.Marshal(template); err != nil {
+		return nil, err
+	} else if err := json.Unmarshal(bytes, &object); err != nil {
+		return nil, err
+	}
+	object["object_type"] = json.RawMessage(jsonString(template.ObjectType()))
+
+	return url.Values{"template_object": {jsonString(object)}}, nil
+}
+
+// returns the form for custom templates, after validating the template ID
+func customForm(templateID int64, templateArgs map[string]string) (url.Values, error) {
+	if templateID <= 0 {
+		return nil, &ValidationError{Field: "template_id", Message: "required"}
+	}
+
+	form := url.Values{"template_id": {strconv.FormatInt(templateID, 10)}}
+	if len(templateArgs) > 0 {
+		form.Set("template_args", jsonString(templateArgs))
+	}
+
+	return form, nil
+}
+
+// returns the form for scrap messages, after validating the request URL
+func scrapForm(requestURL string, templateID int64, templateArgs map[string]string) (url.Values, error) {
+	v := &validator{}
+	v.checkString("request_url", requestURL, true, 0)
+	if err := v.err(); err != nil {
+		return nil, err
+	}
+
+	form := url.Values{"request_url": {requestURL}}
+	if templateID > 0 {
+		form.Set("template_id", strconv.FormatInt(templateID, 10))
+	}
+	if len(templateArgs) > 0 {
+		form.Set("template_args", jsonString(templateArgs))
+	}
+
+	return form, nil
+}
+
+// Message template limits
+//
+// https://developers.kakao.com/docs/latest/ko/message/message-template
+const (
+	messageMaxTextLength        = 200
+	messageMaxHeaderTitleLength = 200
+	messageMaxButtons           = 2
+	messageMinListContents      = 2
+	messageMaxListContents      = 3
+	messageMaxItems             = 5
+	messageMaxReceivers         = 5
+)
+
+// checks if given link has any URL or execution params
+func (v *validator) checkMessageLink(field string, link MessageLink) {
+	if link == (MessageLink{}) {
+		v.fail(field, "required")
+	}
+}
+
+// checks if given content has any of title, description, and image with a link
+func (v *validator) checkMessageContent(field string, content MessageContent) {
+	if len(content.Title) <= 0 && len(content.Description) <= 0 && len(content.ImageURL) <= 0 {
+		v.fail(field, "one of title, description, and image_url is required")
+	}
+	v.checkMessageLink(field+".link", content.Link)
+}
+
+// checks if given buttons are not too many, and have titles and links
+func (v *validator) checkMessageButtons(buttons []MessageButton) {
+	if len(buttons) > messageMaxButtons {
+		v.fail("buttons", "%d buttons exceed %d", len(buttons), messageMaxButtons)
+	}
+	for i, button := range buttons {
+		v.checkString(fmt.Sprintf("buttons[%d].title", i), button.Title, true, 0)
+		v.checkMessageLink(fmt.Sprintf("buttons[%d].link", i), button.Link)
+	}
+}
+
+// Validate checks if the feed template has required fields.
+func (t FeedTemplate) Validate() error {
+	v := &validator{}
+
+	v.checkMessageContent("content", t.Content)
+	if t.ItemContent != nil && len(t.ItemContent.Items) > messageMaxItems {
+		v.fail("item_content.items", "%d items exceed %d", len(t.ItemContent.Items), messageMaxItems)
+	}
+	v.checkMessageButtons(t.Buttons)
+
+	return v.err()
+}
+
+// Validate checks if the list template has required fields and 2 or 3 contents.
+func (t ListTemplate) Validate() error {
+	v := &validator{}
+
+	v.checkString("header_title", t.HeaderTitle, true, messageMaxHeaderTitleLength)
+	v.checkMessageLink("header_link", t.HeaderLink)
+	if len(t.Contents) < messageMinListContents || len(t.Contents) > messageMaxListContents {
+		v.fail("contents", "%d contents are out of range [%d, %d]", len(t.Contents), messageMinListContents, messageMaxListContents)
+	}
+	for i, content := range t.Contents {
+		v.checkMessageContent(fmt.Sprintf("contents[%d]", i), content)
+	}
+	v.checkMessageButtons(t.Buttons)
+
+	return v.err()
+}
+
+// Validate checks if the location template has required fields.
+func (t LocationTemplate) Validate() error {
+	v := &validator{}
+
+	v.checkString("address", t.Address, true, 0)
+	v.checkMessageContent("content", t.Content)
+	v.checkMessageButtons(t.Buttons)
+
+	return v.err()
+}
+
+// Validate checks if the commerce template has required fields.
+func (t CommerceTemplate) Validate() error {
+	v := &validator{}
+
+	v.checkMessageContent("content", t.Content)
+	v.checkString("content.image_url", t.Content.ImageURL, true, 0)
+	if t.Commerce.RegularPrice < 0 {
+		v.fail("commerce.regular_price", "%d is negative", t.Commerce.RegularPrice)
+	}
+	v.checkMessageButtons(t.Buttons)
+
+	return v.err()
+}
+
+// Validate checks if the text template has required fields.
+func (t TextTemplate) Validate() error {
+	v := &validator{}
+
+	v.checkString("text", t.Text, true, messageMaxTextLength)
+	v.checkMessageLink("link", t.Link)
+	v.checkMessageButtons(t.Buttons)
+
+	return v.err()
+}
+
+// Validate checks if the calendar template has required fields.
+func (t CalendarTemplate) Validate() error {
+	v := &validator{}
+
+	if t.IDType != "event" && t.IDType != "calendar" {
+		v.fail("id_type", "'%s' is not one of event, calendar", t.IDType)
+	}
+	v.checkString("id", t.ID, true, 0)
+	v.checkString("content.title", t.Content.Title, true, 0)
+	v.checkMessageLink("content.link", t.Content.Link)
+	v.checkMessageButtons(t.Buttons)
+
+	return v.err()
+}
+
+// checks if the number of receivers is in the range of [1, 5]
+func validateReceivers(receiverUUIDs []string) error {
+	v := &validator{}
+
+	if len(receiverUUIDs) < 1 || len(receiverUUIDs) > messageMaxReceivers {
+		v.fail("receiver_uuids", "%d receivers are out of range [1, %d]", len(receiverUUIDs), messageMaxReceivers)
+	}
+
+	return v.err()
+}
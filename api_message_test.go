@@ -0,0 +1,104 @@
+package kakaoapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestMessageTemplateValidation(t *testing.T) {
+	link := NewMessageLink("https://developers.kakao.com")
+	content := NewMessageContent("title", "description", "https://example.com/image.png", link)
+
+	for name, template := range map[string]MessageTemplate{
+		"feed":     NewFeedTemplate(content).AddButton("open", link),
+		"list":     NewListTemplate("header", link, content, content),
+		"location": NewLocationTemplate("경기 성남시 분당구 판교역로 166", content),
+		"commerce": NewCommerceTemplate(content, MessageCommerce{RegularPrice: 208800}),
+		"text":     NewTextTemplate("hello", link),
+		"calendar": NewCalendarTemplate("event", "event-id", content),
+	} {
+		if err := template.Validate(); err != nil {
+			t.Errorf("valid %s template failed validation: %s", name, err)
+		}
+	}
+
+	for name, template := range map[string]MessageTemplate{
+		"feed without link":         NewFeedTemplate(NewMessageContent("title", "", "", MessageLink{})),
+		"feed with too many button": NewFeedTemplate(content).AddButton("1", link).AddButton("2", link).AddButton("3", link),
+		"list with one content":     NewListTemplate("header", link, content),
+		"location without address":  NewLocationTemplate("", content),
+		"commerce without image":    NewCommerceTemplate(NewMessageContent("title", "", "", link), MessageCommerce{}),
+		"text without text":         NewTextTemplate("", link),
+		"calendar with invalid id":  NewCalendarTemplate("unknown", "", content),
+	} {
+		if err := template.Validate(); err == nil {
+			t.Errorf("invalid template (%s) passed validation", name)
+		}
+	}
+}
+
+func TestSendMessages(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if authorization := r.Header.Get("Authorization"); authorization != "Bearer user-token" {
+			t.Errorf("unexpected authorization: %s", authorization)
+		}
+
+		r.ParseForm()
+		switch r.URL.Path {
+		case "/v2/api/talk/memo/default/send":
+			var object map[string]any
+			if err := json.Unmarshal([]byte(r.PostForm.Get("template_object")), &object); err != nil {
+				t.Errorf("failed to decode template object: %s", err)
+			} else if object["object_type"] != "text" || object["text"] != "hello" {
+				t.Errorf("unexpected template object: %v", object)
+			}
+			w.Write([]byte(`{"result_code":0}`))
+		case "/v2/api/talk/memo/send":
+			if r.PostForm.Get("template_id") != "1234" || r.PostForm.Get("template_args") != `{"name":"tester"}` {
+				t.Errorf("unexpected form: %v", r.PostForm)
+			}
+			w.Write([]byte(`{"result_code":0}`))
+		case "/v1/api/talk/friends/message/scrap/send":
+			if r.PostForm.Get("receiver_uuids") != `["uuid-1","uuid-2"]` || r.PostForm.Get("request_url") != "https://developers.kakao.com" {
+				t.Errorf("unexpected form: %v", r.PostForm)
+			}
+			w.Write([]byte(`{"successful_receiver_uuids":["uuid-1"],"failure_info":[{"code":-532,"msg":"daily limit exceeded","receiver_uuids":["uuid-2"]}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key",
+		WithBaseURL(ServiceKakaoAPI, server.URL),
+		WithTokenSource(StaticTokenSource("user-token")),
+	)
+	ctx := context.Background()
+
+	if res, err := client.SendToMe(ctx, NewTextTemplate("hello", NewMessageLink("https://developers.kakao.com"))); err != nil {
+		t.Errorf("failed to send message to me: %s", err)
+	} else if res.ResultCode != 0 {
+		t.Errorf("unexpected response: %+v", res)
+	}
+
+	if _, err := client.SendToMe(ctx, NewTextTemplate("", MessageLink{})); err == nil {
+		t.Errorf("should have failed with invalid template")
+	}
+
+	if _, err := client.SendCustomToMe(ctx, 1234, map[string]string{"name": "tester"}); err != nil {
+		t.Errorf("failed to send custom message to me: %s", err)
+	}
+
+	if res, err := client.SendScrapToFriends(ctx, []string{"uuid-1", "uuid-2"}, "https://developers.kakao.com", 0, nil); err != nil {
+		t.Errorf("failed to send scrap message to friends: %s", err)
+	} else if len(res.SuccessfulReceiverUUIDs) != 1 || len(res.FailureInfo) != 1 || res.FailureInfo[0].Code != -532 {
+		t.Errorf("unexpected response: %+v", res)
+	}
+
+	if _, err := client.SendCustomToFriends(ctx, []string{"1", "2", "3", "4", "5", "6"}, 1234, nil); err == nil {
+		t.Errorf("should have failed with too many receivers")
+	}
+}
@@ -0,0 +1,216 @@
+package kakaoapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"html"
+	"strings"
+)
+
+// API names of Daum search APIs
+const (
+	APISearchWeb   APIName = "search.web"
+	APISearchVideo APIName = "search.vclip"
+	APISearchImage APIName = "search.image"
+	APISearchBlog  APIName = "search.blog"
+	APISearchBook  APIName = "search.book"
+	APISearchCafe  APIName = "search.cafe"
+)
+
+// path, ranges of page and size, and sorts of each vertical
+type searchVertical struct {
+	api         APIName
+	path        string
+	action      string
+	maxPage     int
+	maxSize     int
+	defaultSize int
+	sorts       []string
+}
+
+// sorts of Daum search verticals
+var (
+	searchSorts     = []string{"accuracy", "recency"}
+	searchBookSorts = []string{"accuracy", "latest"}
+)
+
+// Daum search verticals
+//
+// https://developers.kakao.com/docs/latest/ko/daum-search/dev-guide
+var (
+	searchWeb   = searchVertical{APISearchWeb, "/v2/search/web", "searching web", 50, 50, 10, searchSorts}
+	searchVideo = searchVertical{APISearchVideo, "/v2/search/vclip", "searching videos", 15, 30, 15, searchSorts}
+	searchImage = searchVertical{APISearchImage, "/v2/search/image", "searching images", 50, 80, 80, searchSorts}
+	searchBlog  = searchVertical{APISearchBlog, "/v2/search/blog", "searching blogs", 50, 50, 10, searchSorts}
+	searchBook  = searchVertical{APISearchBook, "/v3/search/book", "searching books", 50, 50, 10, searchBookSorts}
+	searchCafe  = searchVertical{APISearchCafe, "/v2/search/cafe", "searching cafes", 50, 50, 10, searchSorts}
+)
+
+// SearchWeb searches web documents.
+func (c *Client) SearchWeb(ctx context.Context, params ParamsSearch) (ResponseSearch[WebDocument], error) {
+	return search[WebDocument](ctx, c, searchWeb, params)
+}
+
+// SearchWebIterator returns an iterator over all web search results, starting from the page of given params.
+func (c *Client) SearchWebIterator(params ParamsSearch) *Iterator[WebDocument] {
+	return searchIterator[WebDocument](c, searchWeb, params)
+}
+
+// SearchVideo searches video clips.
+func (c *Client) SearchVideo(ctx context.Context, params ParamsSearch) (ResponseSearch[VideoDocument], error) {
+	return search[VideoDocument](ctx, c, searchVideo, params)
+}
+
+// SearchVideoIterator returns an iterator over all video search results, starting from the page of given params.
+func (c *Client) SearchVideoIterator(params ParamsSearch) *Iterator[VideoDocument] {
+	return searchIterator[VideoDocument](c, searchVideo, params)
+}
+
+// SearchImage searches images.
+func (c *Client) SearchImage(ctx context.Context, params ParamsSearch) (ResponseSearch[ImageDocument], error) {
+	return search[ImageDocument](ctx, c, searchImage, params)
+}
+
+// SearchImageIterator returns an iterator over all image search results, starting from the page of given params.
+func (c *Client) SearchImageIterator(params ParamsSearch) *Iterator[ImageDocument] {
+	return searchIterator[ImageDocument](c, searchImage, params)
+}
+
+// SearchBlog searches blog posts.
+func (c *Client) SearchBlog(ctx context.Context, params ParamsSearch) (ResponseSearch[BlogDocument], error) {
+	return search[BlogDocument](ctx, c, searchBlog, params)
+}
+
+// SearchBlogIterator returns an iterator over all blog search results, starting from the page of given params.
+func (c *Client) SearchBlogIterator(params ParamsSearch) *Iterator[BlogDocument] {
+	return searchIterator[BlogDocument](c, searchBlog, params)
+}
+
+// SearchBook searches books.
+func (c *Client) SearchBook(ctx context.Context, params ParamsSearch) (ResponseSearch[BookDocument], error) {
+	return search[BookDocument](ctx, c, searchBook, params)
+}
+
+// SearchBookIterator returns an iterator over all book search results, starting from the page of given params.
+func (c *Client) SearchBookIterator(params ParamsSearch) *Iterator[BookDocument] {
+	return searchIterator[BookDocument](c, searchBook, params)
+}
+
+// SearchCafe searches cafe posts.
+func (c *Client) SearchCafe(ctx context.Context, params ParamsSearch) (ResponseSearch[CafeDocument], error) {
+	return search[CafeDocument](ctx, c, searchCafe, params)
+}
+
+// SearchCafeIterator returns an iterator over all cafe search results, starting from the page of given params.
+func (c *Client) SearchCafeIterator(params ParamsSearch) *Iterator[CafeDocument] {
+	return searchIterator[CafeDocument](c, searchCafe, params)
+}
+
+// searches given vertical with the params
+func search[T any](ctx context.Context, c *Client, vertical searchVertical, params ParamsSearch) (res ResponseSearch[T], err error) {
+	v := &validator{}
+	v.checkIntRange("page", params.Page, 1, vertical.maxPage)
+	v.checkIntRange("size", params.Size, 1, vertical.maxSize)
+	if params.Sort != nil && !containsString(vertical.sorts, *params.Sort) {
+		v.fail("sort", "'%s' is not one of %s", *params.Sort, strings.Join(vertical.sorts, ", "))
+	}
+	if params.Target != nil && vertical.api != APISearchBook {
+		v.fail("target", "only for book search")
+	}
+	if err = errors.Join(params.Validate(), v.err()); err != nil {
+		return ResponseSearch[T]{}, err
+	}
+
+	query := map[string]any{
+		"query": params.Query,
+	}
+	if params.Sort != nil {
+		query["sort"] = *params.Sort
+	}
+	if params.Page != nil {
+		query["page"] = *params.Page
+	}
+	if params.Size != nil {
+		query["size"] = *params.Size
+	}
+	if params.Target != nil {
+		query["target"] = *params.Target
+	}
+
+	var bytes []byte
+	bytes, err = c.get(ctx, vertical.api, c.baseURL(ServiceDapi)+vertical.path, authTypeKakaoAK, nil, query)
+
+	if err == nil {
+		err = json.Unmarshal(bytes, &res)
+		if err == nil {
+			return res, nil
+		} else if c.Verbose {
+			c.logger.Error("failed to decode bytes while "+vertical.action, "bytes", c.redact(string(bytes)))
+		}
+	}
+
+	return ResponseSearch[T]{}, err
+}
+
+// returns an iterator over the pages of given vertical,
+// until `is_end`, the last page, or the end of `pageable_count` documents
+func searchIterator[T any](c *Client, vertical searchVertical, params ParamsSearch) *Iterator[T] {
+	size := vertical.defaultSize
+	if params.Size != nil {
+		size = *params.Size
+	}
+
+	return newIterator(func(ctx context.Context) ([]T, bool, error) {
+		res, err := search[T](ctx, c, vertical, params)
+		if err != nil {
+			return nil, false, err
+		}
+
+		// (`pageable_count` counts from the first page, not the starting one)
+		current := 1
+		if params.Page != nil {
+			current = *params.Page
+		}
+		position := (current-1)*size + len(res.Documents)
+
+		page, hasNext := nextPage(params.Page, res.Meta, vertical.maxPage)
+		params = params.SetPage(page)
+
+		return res.Documents, hasNext && len(res.Documents) > 0 && position < res.Meta.PageableCount, nil
+	})
+}
+
+// checks if given strings contain the value
+func containsString(strs []string, value string) bool {
+	for _, str := range strs {
+		if str == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+// replacer of highlight tags in search results
+var highlightReplacer = strings.NewReplacer("<b>", "", "</b>", "")
+
+// StripHighlight removes highlight tags (`<b>`, `</b>`) from given search result text,
+// and unescapes its HTML entities.
+func StripHighlight(text string) string {
+	return html.UnescapeString(highlightReplacer.Replace(text))
+}
+
+// Validate checks if the parameters have a query, and a valid target.
+//
+// (sorts and ranges of page and size differ by vertical, so they are checked when searching)
+func (p ParamsSearch) Validate() error {
+	v := &validator{}
+
+	v.checkString("query", p.Query, true, 0)
+	if p.Target != nil && *p.Target != "title" && *p.Target != "isbn" && *p.Target != "publisher" && *p.Target != "person" {
+		v.fail("target", "'%s' is not one of title, isbn, publisher, person", *p.Target)
+	}
+
+	return v.err()
+}
@@ -0,0 +1,91 @@
+package kakaoapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+)
+
+func TestSearchAPIs(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		if authorization := r.Header.Get("Authorization"); authorization != "KakaoAK test-api-key" {
+			t.Errorf("unexpected authorization: %s", authorization)
+		}
+
+		query := r.URL.Query()
+		switch r.URL.Path {
+		case "/v2/search/web":
+			// 5 pageable documents, though `is_end` is never true
+			page, _ := strconv.Atoi(query.Get("page"))
+			if page <= 0 {
+				page = 1
+			}
+			fmt.Fprintf(w, `{"meta":{"total_count":1000,"pageable_count":5,"is_end":false},"documents":[{"title":"<b>카카오</b> %d","contents":"","url":"https://example.com/%d","datetime":"2023-05-07T18:50:00.000+09:00"},{"title":"page %d","contents":"","url":"","datetime":""}]}`, page, page, page)
+		case "/v3/search/book":
+			if query.Get("target") != "isbn" {
+				t.Errorf("unexpected query: %s", r.URL.RawQuery)
+			}
+			w.Write([]byte(`{"meta":{"total_count":1,"pageable_count":1,"is_end":true},"documents":[{"title":"미움받을 용기","isbn":"8996991341 9788996991342","authors":["기시미 이치로","고가 후미타케"],"price":14900,"sale_price":13410,"datetime":"2014-11-17T00:00:00.000+09:00"}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(ServiceDapi, server.URL))
+	ctx := context.Background()
+
+	documents, err := client.SearchWebIterator(NewParamsSearch("카카오").SetSize(2)).All(ctx)
+	if err != nil {
+		t.Fatalf("failed to iterate web documents: %s", err)
+	}
+	if len(documents) != 6 || atomic.LoadInt32(&requests) != 3 {
+		t.Errorf("expected 6 documents from 3 pages, got %d documents from %d pages", len(documents), atomic.LoadInt32(&requests))
+	}
+	if StripHighlight(documents[0].Title) != "카카오 1" || documents[0].Datetime.Year() != 2023 || !documents[1].Datetime.IsZero() {
+		t.Errorf("unexpected document: %+v", documents[0])
+	}
+
+	// (`pageable_count` counts from the first page, so iterating from the 2nd page fetches 2 pages)
+	atomic.StoreInt32(&requests, 0)
+	if documents, err := client.SearchWebIterator(NewParamsSearch("카카오").SetSize(2).SetPage(2)).All(ctx); err != nil {
+		t.Errorf("failed to iterate web documents from the 2nd page: %s", err)
+	} else if len(documents) != 4 || atomic.LoadInt32(&requests) != 2 {
+		t.Errorf("expected 4 documents from 2 pages, got %d documents from %d pages", len(documents), atomic.LoadInt32(&requests))
+	}
+
+	if books, err := client.SearchBook(ctx, NewParamsSearch("9788996991342").SetTarget("isbn")); err != nil {
+		t.Errorf("failed to search books: %s", err)
+	} else if len(books.Documents) != 1 || len(books.Documents[0].Authors) != 2 || books.Documents[0].SalePrice != 13410 {
+		t.Errorf("unexpected books: %+v", books)
+	}
+
+	// ranges are checked per vertical
+	if _, err := client.SearchVideo(ctx, NewParamsSearch("카카오").SetPage(16)); err == nil {
+		t.Errorf("should have failed with invalid page")
+	}
+	if _, err := client.SearchImage(ctx, NewParamsSearch("카카오").SetSize(80)); err != nil && AsAPIError(err) == nil {
+		t.Errorf("should have passed validation: %s", err)
+	}
+	if _, err := client.SearchBook(ctx, NewParamsSearch("카카오").SetSort("recency")); err == nil {
+		t.Errorf("should have failed with invalid sort for book search")
+	}
+	if _, err := client.SearchWeb(ctx, NewParamsSearch("카카오").SetSort("latest")); err == nil {
+		t.Errorf("should have failed with invalid sort for web search")
+	}
+	if _, err := client.SearchCafe(ctx, NewParamsSearch("카카오").SetTarget("isbn")); err == nil {
+		t.Errorf("should have failed with target for non-book search")
+	}
+}
+
+func TestStripHighlight(t *testing.T) {
+	if stripped := StripHighlight("<b>Kakao</b> &amp; <b>Daum</b>&#39;s"); stripped != "Kakao & Daum's" {
+		t.Errorf("unexpected stripped text: %s", stripped)
+	}
+}
@@ -0,0 +1,122 @@
+package kakaoapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"strconv"
+)
+
+// API names of KakaoTalk social APIs
+const (
+	APITalkProfile APIName = "talk.profile"
+	APITalkFriends APIName = "talk.friends"
+)
+
+// TalkProfile returns the current user's KakaoTalk profile.
+//
+// https://developers.kakao.com/docs/latest/ko/kakaotalk-social/rest-api#get-profile
+func (c *Client) TalkProfile(ctx context.Context) (res ResponseTalkProfile, err error) {
+	var bytes []byte
+	bytes, err = c.get(ctx, APITalkProfile, c.baseURL(ServiceKakaoAPI)+"/v1/api/talk/profile", authTypeBearer, nil, nil)
+
+	if err == nil {
+		err = json.Unmarshal(bytes, &res)
+		if err == nil {
+			return res, nil
+		} else if c.Verbose {
+			c.logger.Error("failed to decode bytes while fetching talk profile", "bytes", c.redact(string(bytes)))
+		}
+	}
+
+	return ResponseTalkProfile{}, err
+}
+
+// Friends returns a page of the current user's KakaoTalk friends.
+//
+// https://developers.kakao.com/docs/latest/ko/kakaotalk-social/rest-api#get-friends
+func (c *Client) Friends(ctx context.Context, params ParamsFriends) (res ResponseFriends, err error) {
+	if err = params.Validate(); err != nil {
+		return ResponseFriends{}, err
+	}
+
+	query := map[string]any{}
+	if params.Offset != nil {
+		query["offset"] = *params.Offset
+	}
+	if params.Limit != nil {
+		query["limit"] = *params.Limit
+	}
+	if params.Order != nil {
+		query["order"] = *params.Order
+	}
+	if params.FriendOrder != nil {
+		query["friend_order"] = *params.FriendOrder
+	}
+
+	var bytes []byte
+	bytes, err = c.get(ctx, APITalkFriends, c.baseURL(ServiceKakaoAPI)+"/v1/api/talk/friends", authTypeBearer, nil, query)
+
+	if err == nil {
+		err = json.Unmarshal(bytes, &res)
+		if err == nil {
+			return res, nil
+		} else if c.Verbose {
+			c.logger.Error("failed to decode bytes while fetching friends", "bytes", c.redact(string(bytes)))
+		}
+	}
+
+	return ResponseFriends{}, err
+}
+
+// FriendsIterator returns an iterator over all friends, starting from the offset of given params.
+//
+// Pages are fetched with the offset of each page's `after_url`.
+func (c *Client) FriendsIterator(params ParamsFriends) *Iterator[Friend] {
+	return newIterator(func(ctx context.Context) ([]Friend, bool, error) {
+		res, err := c.Friends(ctx, params)
+		if err != nil {
+			return nil, false, err
+		}
+
+		offset, ok := offsetOf(res.AfterURL)
+		if ok {
+			params = params.SetOffset(offset)
+		}
+
+		return res.Elements, ok && len(res.Elements) > 0, nil
+	})
+}
+
+// returns the `offset` query parameter of given page URL
+func offsetOf(pageURL string) (offset int, ok bool) {
+	if len(pageURL) <= 0 {
+		return 0, false
+	}
+
+	if u, err := url.Parse(pageURL); err == nil {
+		if offset, err = strconv.Atoi(u.Query().Get("offset")); err == nil {
+			return offset, true
+		}
+	}
+
+	return 0, false
+}
+
+// Validate checks if the offset, limit, and orders are in the documented ranges.
+func (p ParamsFriends) Validate() error {
+	v := &validator{}
+
+	if p.Offset != nil && *p.Offset < 0 {
+		v.fail("offset", "%d is negative", *p.Offset)
+	}
+	v.checkIntRange("limit", p.Limit, 1, 100)
+	if p.Order != nil && *p.Order != "asc" && *p.Order != "desc" {
+		v.fail("order", "'%s' is not one of asc, desc", *p.Order)
+	}
+	if p.FriendOrder != nil && *p.FriendOrder != "nickname" && *p.FriendOrder != "favorite" {
+		v.fail("friend_order", "'%s' is not one of nickname, favorite", *p.FriendOrder)
+	}
+
+	return v.err()
+}
@@ -0,0 +1,108 @@
+package kakaoapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+)
+
+// returns a stub server of KakaoTalk social APIs with `total` friends
+func newTalkServer(t *testing.T, total int) (server *httptest.Server, requests *int32) {
+	requests = new(int32)
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(requests, 1)
+		if authorization := r.Header.Get("Authorization"); authorization != "Bearer user-token" {
+			t.Errorf("unexpected authorization: %s", authorization)
+		}
+
+		switch r.URL.Path {
+		case "/v1/api/talk/profile":
+			w.Write([]byte(`{"nickName":"tester","profileImageURL":"https://example.com/profile.png","thumbnailURL":"https://example.com/thumbnail.png","countryISO":"KR"}`))
+		case "/v1/api/talk/friends":
+			offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+			limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+			if limit <= 0 {
+				limit = 10
+			}
+
+			elements := "["
+			for i := offset; i < offset+limit && i < total; i++ {
+				if i > offset {
+					elements += ","
+				}
+				elements += fmt.Sprintf(`{"id":%d,"uuid":"uuid-%d","favorite":false,"profile_nickname":"friend %d"}`, i, i, i)
+			}
+			elements += "]"
+
+			afterURL := ""
+			if offset+limit < total {
+				afterURL = fmt.Sprintf("https://kapi.kakao.com/v1/api/talk/friends?offset=%d&limit=%d&order=asc", offset+limit, limit)
+			}
+			fmt.Fprintf(w, `{"elements":%s,"total_count":%d,"favorite_count":0,"after_url":"%s"}`, elements, total, afterURL)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	return server, requests
+}
+
+func TestTalkProfile(t *testing.T) {
+	server, _ := newTalkServer(t, 0)
+	defer server.Close()
+
+	client := NewClient("test-api-key",
+		WithBaseURL(ServiceKakaoAPI, server.URL),
+		WithTokenSource(StaticTokenSource("user-token")),
+	)
+
+	if profile, err := client.TalkProfile(context.Background()); err != nil {
+		t.Errorf("failed to fetch talk profile: %s", err)
+	} else if profile.Nickname != "tester" || profile.CountryISO != "KR" {
+		t.Errorf("unexpected profile: %+v", profile)
+	}
+}
+
+func TestFriendsIterator(t *testing.T) {
+	server, requests := newTalkServer(t, 7)
+	defer server.Close()
+
+	client := NewClient("test-api-key",
+		WithBaseURL(ServiceKakaoAPI, server.URL),
+		WithTokenSource(StaticTokenSource("user-token")),
+	)
+
+	friends, err := client.FriendsIterator(NewParamsFriends().SetLimit(3)).All(context.Background())
+	if err != nil {
+		t.Fatalf("failed to iterate friends: %s", err)
+	}
+	if len(friends) != 7 || friends[0].UUID != "uuid-0" || friends[6].UUID != "uuid-6" {
+		t.Errorf("unexpected friends: %+v", friends)
+	}
+	if n := atomic.LoadInt32(requests); n != 3 {
+		t.Errorf("expected 3 pages to be fetched, got %d", n)
+	}
+
+	// canceled context stops the iteration before fetching the next page
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	it := client.FriendsIterator(NewParamsFriends().SetLimit(3))
+	count := 0
+	for it.Next(ctx) {
+		if count++; count == 3 {
+			cancel()
+		}
+	}
+	if count != 3 || !errors.Is(it.Err(), context.Canceled) {
+		t.Errorf("unexpected iteration after cancel: %d items, error: %v", count, it.Err())
+	}
+
+	if _, err := client.Friends(context.Background(), NewParamsFriends().SetLimit(101)); err == nil {
+		t.Errorf("should have failed with invalid limit")
+	}
+}
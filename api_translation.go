@@ -0,0 +1,211 @@
+package kakaoapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"strings"
+	"unicode"
+)
+
+// API names of translation APIs
+const (
+	APITranslationTranslate APIName = "translation.translate"
+	APITranslationDetect    APIName = "translation.detect"
+)
+
+// maximum number of characters per request
+//
+// https://developers.kakao.com/docs/latest/ko/translate/dev-guide
+const (
+	translationMaxQueryLength = 5000
+	detectionMaxQueryLength   = 1000
+)
+
+// Translate translates given text from `src` to `target` language.
+//
+// The language of the text is detected if `src` is empty.
+// Text longer than the per-request limit is split at line, sentence, or word boundaries,
+// translated chunk by chunk, and reassembled.
+//
+// https://developers.kakao.com/docs/latest/ko/translate/dev-guide#trans-sentence
+func (c *Client) Translate(ctx context.Context, text string, src, target LanguageCode) (translated string, err error) {
+	v := &validator{}
+	v.checkString("query", strings.TrimSpace(text), true, 0)
+	v.checkLanguage("src_lang", src, false)
+	v.checkLanguage("target_lang", target, true)
+	if err = v.err(); err != nil {
+		return "", err
+	}
+
+	if len(src) <= 0 {
+		if src, err = c.detectedLanguage(ctx, text); err != nil {
+			return "", err
+		}
+	}
+	if src == target {
+		return text, nil
+	}
+
+	var sb strings.Builder
+	for _, chunk := range chunkText(text, translationMaxQueryLength) {
+		var res ResponseTranslation
+		if res, err = c.translateChunk(ctx, chunk.text, src, target); err != nil {
+			return "", err
+		}
+
+		lines := make([]string, len(res.TranslatedText))
+		for i, sentences := range res.TranslatedText {
+			lines[i] = strings.Join(sentences, " ")
+		}
+		sb.WriteString(strings.Join(lines, "\n"))
+		sb.WriteString(chunk.separator)
+	}
+
+	return sb.String(), nil
+}
+
+// translates given text which fits in a single request
+func (c *Client) translateChunk(ctx context.Context, text string, src, target LanguageCode) (res ResponseTranslation, err error) {
+	var bytes []byte
+	bytes, err = c.post(ctx, APITranslationTranslate, c.baseURL(ServiceDapi)+"/v2/translation/translate", authTypeKakaoAK, nil, url.Values{
+		"query":       {text},
+		"src_lang":    {string(src)},
+		"target_lang": {string(target)},
+	})
+
+	if err == nil {
+		err = json.Unmarshal(bytes, &res)
+		if err == nil {
+			return res, nil
+		} else if c.Verbose {
+			c.logger.Error("failed to decode bytes while translating", "bytes", c.redact(string(bytes)))
+		}
+	}
+
+	return ResponseTranslation{}, err
+}
+
+// DetectLanguage detects the language of given text.
+//
+// Only the leading part of the text within the per-request limit is used.
+//
+// https://developers.kakao.com/docs/latest/ko/translate/dev-guide#language-detect
+func (c *Client) DetectLanguage(ctx context.Context, text string) (res ResponseLanguageDetection, err error) {
+	v := &validator{}
+	v.checkString("query", strings.TrimSpace(text), true, 0)
+	if err = v.err(); err != nil {
+		return ResponseLanguageDetection{}, err
+	}
+
+	if runes := []rune(text); len(runes) > detectionMaxQueryLength {
+		text = string(runes[:detectionMaxQueryLength])
+	}
+
+	var bytes []byte
+	bytes, err = c.post(ctx, APITranslationDetect, c.baseURL(ServiceDapi)+"/v3/translation/language/detect", authTypeKakaoAK, nil, url.Values{
+		"query": {text},
+	})
+
+	if err == nil {
+		err = json.Unmarshal(bytes, &res)
+		if err == nil {
+			return res, nil
+		} else if c.Verbose {
+			c.logger.Error("failed to decode bytes while detecting language", "bytes", c.redact(string(bytes)))
+		}
+	}
+
+	return ResponseLanguageDetection{}, err
+}
+
+// returns the most confident language of given text
+func (c *Client) detectedLanguage(ctx context.Context, text string) (LanguageCode, error) {
+	res, err := c.DetectLanguage(ctx, text)
+	if err != nil {
+		return "", err
+	}
+
+	var code LanguageCode
+	var confidence float64 = -1
+	for _, info := range res.LanguageInfo {
+		if info.Confidence > confidence {
+			code, confidence = info.Code, info.Confidence
+		}
+	}
+	if len(code) <= 0 {
+		return "", &ValidationError{Field: "src_lang", Message: "could not detect the language"}
+	}
+
+	return code, nil
+}
+
+// chunk of text, and the separator which followed it in the original text
+type textChunk struct {
+	text      string
+	separator string
+}
+
+// splits given text into chunks of at most `limit` characters,
+// preferably at line, then sentence, then word boundaries
+func chunkText(text string, limit int) (chunks []textChunk) {
+	runes := []rune(text)
+	for len(runes) > limit {
+		cut, separator := chunkBoundary(runes[:limit+1])
+		chunks = append(chunks, textChunk{text: string(runes[:cut]), separator: separator})
+		runes = runes[cut+len([]rune(separator)):]
+	}
+	if len(runes) > 0 {
+		chunks = append(chunks, textChunk{text: string(runes)})
+	}
+
+	return chunks
+}
+
+// returns the index where given runes should be cut, and the separator at the index
+// (the boundary is searched in the latter half, so that chunks are not too small)
+func chunkBoundary(runes []rune) (cut int, separator string) {
+	half := len(runes) / 2
+
+	// line boundary
+	for i := len(runes) - 1; i >= half; i-- {
+		if runes[i] == '\n' {
+			return i, "\n"
+		}
+	}
+
+	// sentence boundary (punctuation followed by a space)
+	for i := len(runes) - 1; i > half; i-- {
+		if unicode.IsSpace(runes[i]) && strings.ContainsRune(".?!。？！", runes[i-1]) {
+			return i, string(runes[i])
+		}
+	}
+
+	// word boundary
+	for i := len(runes) - 1; i >= half; i-- {
+		if unicode.IsSpace(runes[i]) {
+			return i, string(runes[i])
+		}
+	}
+
+	return len(runes) - 1, ""
+}
+
+// checks if given language code (if set) is one of the supported ones
+func (v *validator) checkLanguage(field string, code LanguageCode, required bool) {
+	if len(code) <= 0 {
+		if required {
+			v.fail(field, "required")
+		}
+		return
+	}
+
+	switch code {
+	case LanguageKorean, LanguageEnglish, LanguageJapanese, LanguageChinese, LanguageVietnamese,
+		LanguageIndonesian, LanguageArabic, LanguageBengali, LanguageGerman, LanguageSpanish,
+		LanguageFrench, LanguageHindi, LanguageItalian, LanguageMalay, LanguageDutch,
+		LanguagePortuguese, LanguageRussian, LanguageThai, LanguageTurkish:
+	default:
+		v.fail(field, "'%s' is not a supported language", code)
+	}
+}
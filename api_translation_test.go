@@ -0,0 +1,103 @@
+package kakaoapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestTranslation(t *testing.T) {
+	var translations int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if authorization := r.Header.Get("Authorization"); authorization != "KakaoAK test-api-key" {
+			t.Errorf("unexpected authorization: %s", authorization)
+		}
+
+		r.ParseForm()
+		query := r.PostForm.Get("query")
+		switch r.URL.Path {
+		case "/v3/translation/language/detect":
+			w.Write([]byte(`{"language_info":[{"code":"en","name":"English","confidence":0.2},{"code":"kr","name":"Korean","confidence":0.9}]}`))
+		case "/v2/translation/translate":
+			atomic.AddInt32(&translations, 1)
+			if r.PostForm.Get("src_lang") != "kr" || r.PostForm.Get("target_lang") != "en" {
+				t.Errorf("unexpected form: %v", r.PostForm)
+			}
+			if length := utf8.RuneCountInString(query); length > translationMaxQueryLength {
+				t.Errorf("query length %d exceeds the limit", length)
+			}
+
+			// "translates" each line into its rune count
+			var translated [][]string
+			for _, line := range strings.Split(query, "\n") {
+				translated = append(translated, []string{"[" + strings.Repeat("x", utf8.RuneCountInString(line)%10) + "]"})
+			}
+			bytes, _ := json.Marshal(map[string]any{"translated_text": translated})
+			w.Write(bytes)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(ServiceDapi, server.URL))
+	ctx := context.Background()
+
+	if detected, err := client.DetectLanguage(ctx, "안녕하세요"); err != nil {
+		t.Errorf("failed to detect language: %s", err)
+	} else if len(detected.LanguageInfo) != 2 {
+		t.Errorf("unexpected detection: %+v", detected)
+	}
+
+	// (source language is detected)
+	if translated, err := client.Translate(ctx, "안녕\n하세요", "", LanguageEnglish); err != nil {
+		t.Errorf("failed to translate: %s", err)
+	} else if translated != "[xx]\n[xxx]" {
+		t.Errorf("unexpected translation: %s", translated)
+	}
+
+	// long text is chunked and reassembled
+	line := strings.Repeat("가", 2999)
+	atomic.StoreInt32(&translations, 0)
+	if translated, err := client.Translate(ctx, line+"\n"+line+"\n"+line, LanguageKorean, LanguageEnglish); err != nil {
+		t.Errorf("failed to translate long text: %s", err)
+	} else if translated != "[xxxxxxxxx]\n[xxxxxxxxx]\n[xxxxxxxxx]" {
+		t.Errorf("unexpected translation of long text: %s", translated)
+	}
+	if n := atomic.LoadInt32(&translations); n != 3 {
+		t.Errorf("expected 3 chunks, got %d", n)
+	}
+
+	if _, err := client.Translate(ctx, "text", LanguageKorean, "xx"); err == nil {
+		t.Errorf("should have failed with unsupported language")
+	}
+}
+
+func TestChunkText(t *testing.T) {
+	text := "First sentence. Second sentence! Third one without end"
+	chunks := chunkText(text, 20)
+
+	var sb strings.Builder
+	for _, chunk := range chunks {
+		if utf8.RuneCountInString(chunk.text) > 20 {
+			t.Errorf("chunk exceeds the limit: %q", chunk.text)
+		}
+		sb.WriteString(chunk.text + chunk.separator)
+	}
+	if sb.String() != text {
+		t.Errorf("chunks do not reassemble into the original text: %q", sb.String())
+	}
+	if chunks[0].text != "First sentence." {
+		t.Errorf("should have been split at sentence boundary: %q", chunks[0].text)
+	}
+
+	// text without any boundary is split hard
+	if chunks := chunkText(strings.Repeat("가", 25), 10); len(chunks) != 3 || chunks[2].text != strings.Repeat("가", 5) {
+		t.Errorf("unexpected hard split: %+v", chunks)
+	}
+}
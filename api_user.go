@@ -0,0 +1,200 @@
+package kakaoapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"strconv"
+)
+
+// API names of user management APIs
+const (
+	APIUserMe            APIName = "user.me"
+	APIUserScopes        APIName = "user.scopes"
+	APIUserRevokeScopes  APIName = "user.revoke_scopes"
+	APIUserUpdateProfile APIName = "user.update_profile"
+	APIUserIDs           APIName = "user.ids"
+	APIUserUnlink        APIName = "user.unlink"
+)
+
+// ErrNoAdminKey is returned when an API which needs the admin key is called without it.
+var ErrNoAdminKey = errors.New("no admin key for admin-authenticated API")
+
+// UserInfo returns the current user's information.
+//
+// Only given property keys (eg. "kakao_account.email", "properties.nickname") are returned if any.
+//
+// https://developers.kakao.com/docs/latest/ko/kakaologin/rest-api#req-user-info
+func (c *Client) UserInfo(ctx context.Context, propertyKeys ...string) (res ResponseUserInfo, err error) {
+	params := map[string]any{
+		"secure_resource": true,
+	}
+	if len(propertyKeys) > 0 {
+		params["property_keys"] = jsonString(propertyKeys)
+	}
+
+	var bytes []byte
+	bytes, err = c.get(ctx, APIUserMe, c.baseURL(ServiceKakaoAPI)+"/v2/user/me", authTypeBearer, nil, params)
+
+	if err == nil {
+		err = json.Unmarshal(bytes, &res)
+		if err == nil {
+			return res, nil
+		} else if c.Verbose {
+			c.logger.Error("failed to decode bytes while fetching user info", "bytes", c.redact(string(bytes)))
+		}
+	}
+
+	return ResponseUserInfo{}, err
+}
+
+// Scopes returns the current user's consent scopes.
+//
+// Only given scopes are returned if any.
+//
+// https://developers.kakao.com/docs/latest/ko/kakaologin/rest-api#check-consent
+func (c *Client) Scopes(ctx context.Context, scopes ...string) (res ResponseScopes, err error) {
+	params := map[string]any{}
+	if len(scopes) > 0 {
+		params["scopes"] = jsonString(scopes)
+	}
+
+	var bytes []byte
+	bytes, err = c.get(ctx, APIUserScopes, c.baseURL(ServiceKakaoAPI)+"/v2/user/scopes", authTypeBearer, nil, params)
+
+	if err == nil {
+		err = json.Unmarshal(bytes, &res)
+		if err == nil {
+			return res, nil
+		} else if c.Verbose {
+			c.logger.Error("failed to decode bytes while fetching scopes", "bytes", c.redact(string(bytes)))
+		}
+	}
+
+	return ResponseScopes{}, err
+}
+
+// RevokeScopes revokes the current user's consent for given scopes, and returns the remaining scopes.
+//
+// https://developers.kakao.com/docs/latest/ko/kakaologin/rest-api#revoke-consent
+func (c *Client) RevokeScopes(ctx context.Context, scopes ...string) (res ResponseScopes, err error) {
+	if len(scopes) <= 0 {
+		return ResponseScopes{}, &ValidationError{Field: "scopes", Message: "required"}
+	}
+
+	var bytes []byte
+	bytes, err = c.post(ctx, APIUserRevokeScopes, c.baseURL(ServiceKakaoAPI)+"/v2/user/revoke/scopes", authTypeBearer, nil, url.Values{
+		"scopes": {jsonString(scopes)},
+	})
+
+	if err == nil {
+		err = json.Unmarshal(bytes, &res)
+		if err == nil {
+			return res, nil
+		} else if c.Verbose {
+			c.logger.Error("failed to decode bytes while revoking scopes", "bytes", c.redact(string(bytes)))
+		}
+	}
+
+	return ResponseScopes{}, err
+}
+
+// UpdateProfile saves given properties to the current user's custom properties.
+//
+// https://developers.kakao.com/docs/latest/ko/kakaologin/rest-api#save-user-info
+func (c *Client) UpdateProfile(ctx context.Context, properties map[string]string) (res ResponseUserID, err error) {
+	if len(properties) <= 0 {
+		return ResponseUserID{}, &ValidationError{Field: "properties", Message: "required"}
+	}
+
+	var bytes []byte
+	bytes, err = c.post(ctx, APIUserUpdateProfile, c.baseURL(ServiceKakaoAPI)+"/v1/user/update_profile", authTypeBearer, nil, url.Values{
+		"properties": {jsonString(properties)},
+	})
+
+	if err == nil {
+		err = json.Unmarshal(bytes, &res)
+		if err == nil {
+			return res, nil
+		} else if c.Verbose {
+			c.logger.Error("failed to decode bytes while updating profile", "bytes", c.redact(string(bytes)))
+		}
+	}
+
+	return ResponseUserID{}, err
+}
+
+// UserIDs returns the IDs of the app's users, using the admin key.
+//
+// https://developers.kakao.com/docs/latest/ko/kakaologin/rest-api#user-list
+func (c *Client) UserIDs(ctx context.Context, params ParamsUserIDs) (res ResponseUserIDs, err error) {
+	if err = params.Validate(); err != nil {
+		return ResponseUserIDs{}, err
+	}
+
+	query := map[string]any{}
+	if params.Limit != nil {
+		query["limit"] = *params.Limit
+	}
+	if params.FromID != nil {
+		query["from_id"] = *params.FromID
+	}
+	if params.Order != nil {
+		query["order"] = *params.Order
+	}
+
+	var bytes []byte
+	bytes, err = c.get(ctx, APIUserIDs, c.baseURL(ServiceKakaoAPI)+"/v1/user/ids", authTypeAdminKey, nil, query)
+
+	if err == nil {
+		err = json.Unmarshal(bytes, &res)
+		if err == nil {
+			return res, nil
+		} else if c.Verbose {
+			c.logger.Error("failed to decode bytes while fetching user ids", "bytes", c.redact(string(bytes)))
+		}
+	}
+
+	return ResponseUserIDs{}, err
+}
+
+// UnlinkUser disconnects the user with given ID from the app, using the admin key.
+//
+// https://developers.kakao.com/docs/latest/ko/kakaologin/rest-api#unlink
+func (c *Client) UnlinkUser(ctx context.Context, userID int64) (res ResponseUserID, err error) {
+	var bytes []byte
+	bytes, err = c.post(ctx, APIUserUnlink, c.baseURL(ServiceKakaoAPI)+"/v1/user/unlink", authTypeAdminKey, nil, url.Values{
+		"target_id_type": {"user_id"},
+		"target_id":      {strconv.FormatInt(userID, 10)},
+	})
+
+	if err == nil {
+		err = json.Unmarshal(bytes, &res)
+		if err == nil {
+			return res, nil
+		} else if c.Verbose {
+			c.logger.Error("failed to decode bytes while unlinking user", "bytes", c.redact(string(bytes)))
+		}
+	}
+
+	return ResponseUserID{}, err
+}
+
+// returns the JSON representation of given value for form or query parameters
+func jsonString(v any) string {
+	bytes, _ := json.Marshal(v)
+	return string(bytes)
+}
+
+// Validate checks if the limit and order are in the documented ranges.
+func (p ParamsUserIDs) Validate() error {
+	v := &validator{}
+
+	v.checkIntRange("limit", p.Limit, 1, 100)
+	if p.Order != nil && *p.Order != "asc" && *p.Order != "desc" {
+		v.fail("order", "'%s' is not one of asc, desc", *p.Order)
+	}
+
+	return v.err()
+}
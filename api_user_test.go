@@ -0,0 +1,114 @@
+package kakaoapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestUserAPIs(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization := r.Header.Get("Authorization")
+		switch r.URL.Path {
+		case "/v1/user/ids", "/v1/user/unlink":
+			if authorization != "KakaoAK test-admin-key" {
+				t.Errorf("unexpected authorization for %s: %s", r.URL.Path, authorization)
+			}
+		default:
+			if authorization != "Bearer user-token" {
+				t.Errorf("unexpected authorization for %s: %s", r.URL.Path, authorization)
+			}
+		}
+
+		r.ParseForm()
+		switch r.URL.Path {
+		case "/v2/user/me":
+			if keys := r.Form.Get("property_keys"); keys != `["kakao_account.email","kakao_account.profile"]` {
+				t.Errorf("unexpected property keys: %s", keys)
+			}
+			w.Write([]byte(`{"id":1234,"connected_at":"2022-04-11T01:45:28Z","properties":{"nickname":"tester"},"kakao_account":{"profile_needs_agreement":false,"profile":{"nickname":"tester","is_default_image":true},"email_needs_agreement":false,"is_email_valid":true,"email":"tester@example.com"}}`))
+		case "/v2/user/scopes":
+			w.Write([]byte(`{"id":1234,"scopes":[{"id":"profile_nickname","display_name":"닉네임","type":"PRIVACY","using":true,"agreed":true,"revocable":false},{"id":"talk_message","display_name":"카카오톡 메시지 전송","type":"SERVICE","using":true,"agreed":true,"revocable":true}]}`))
+		case "/v2/user/revoke/scopes":
+			if scopes := r.PostForm.Get("scopes"); scopes != `["talk_message"]` {
+				t.Errorf("unexpected scopes: %s", scopes)
+			}
+			w.Write([]byte(`{"id":1234,"scopes":[{"id":"talk_message","display_name":"카카오톡 메시지 전송","type":"SERVICE","using":true,"agreed":false,"revocable":true}]}`))
+		case "/v1/user/update_profile":
+			if properties := r.PostForm.Get("properties"); properties != `{"level":"10"}` {
+				t.Errorf("unexpected properties: %s", properties)
+			}
+			w.Write([]byte(`{"id":1234}`))
+		case "/v1/user/ids":
+			if r.Form.Get("limit") != "2" || r.Form.Get("order") != "desc" {
+				t.Errorf("unexpected query: %s", r.URL.RawQuery)
+			}
+			w.Write([]byte(`{"elements":[1234,1233],"total_count":10,"after_url":"https://kapi.kakao.com/v1/user/ids?limit=2&order=desc&from_id=1233"}`))
+		case "/v1/user/unlink":
+			if r.PostForm.Get("target_id_type") != "user_id" || r.PostForm.Get("target_id") != "1234" {
+				t.Errorf("unexpected form: %v", r.PostForm)
+			}
+			w.Write([]byte(`{"id":1234}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key",
+		WithBaseURL(ServiceKakaoAPI, server.URL),
+		WithAdminKey("test-admin-key"),
+		WithTokenSource(StaticTokenSource("user-token")),
+	)
+	ctx := context.Background()
+
+	if info, err := client.UserInfo(ctx, "kakao_account.email", "kakao_account.profile"); err != nil {
+		t.Errorf("failed to fetch user info: %s", err)
+	} else if info.ID != 1234 || info.KakaoAccount == nil || info.KakaoAccount.Email == nil || *info.KakaoAccount.Email != "tester@example.com" ||
+		info.KakaoAccount.Profile == nil || info.KakaoAccount.Profile.Nickname != "tester" || info.ConnectedAt == nil {
+		t.Errorf("unexpected user info: %+v", info)
+	}
+
+	if scopes, err := client.Scopes(ctx); err != nil {
+		t.Errorf("failed to fetch scopes: %s", err)
+	} else if len(scopes.Scopes) != 2 || scopes.Scopes[1].ID != "talk_message" || !scopes.Scopes[1].Agreed {
+		t.Errorf("unexpected scopes: %+v", scopes)
+	}
+
+	if _, err := client.RevokeScopes(ctx); err == nil {
+		t.Errorf("should have failed without scopes")
+	}
+	if scopes, err := client.RevokeScopes(ctx, "talk_message"); err != nil {
+		t.Errorf("failed to revoke scopes: %s", err)
+	} else if len(scopes.Scopes) != 1 || scopes.Scopes[0].Agreed {
+		t.Errorf("unexpected scopes after revocation: %+v", scopes)
+	}
+
+	if res, err := client.UpdateProfile(ctx, map[string]string{"level": "10"}); err != nil {
+		t.Errorf("failed to update profile: %s", err)
+	} else if res.ID != 1234 {
+		t.Errorf("unexpected response: %+v", res)
+	}
+
+	if _, err := client.UserIDs(ctx, NewParamsUserIDs().SetLimit(101)); err == nil {
+		t.Errorf("should have failed with invalid limit")
+	}
+	if ids, err := client.UserIDs(ctx, NewParamsUserIDs().SetLimit(2).SetOrder("desc")); err != nil {
+		t.Errorf("failed to fetch user ids: %s", err)
+	} else if len(ids.Elements) != 2 || ids.TotalCount != 10 || len(ids.AfterURL) <= 0 {
+		t.Errorf("unexpected user ids: %+v", ids)
+	}
+
+	if res, err := client.UnlinkUser(ctx, 1234); err != nil {
+		t.Errorf("failed to unlink user: %s", err)
+	} else if res.ID != 1234 {
+		t.Errorf("unexpected response: %+v", res)
+	}
+
+	// admin APIs need the admin key
+	if _, err := NewClient("test-api-key", WithBaseURL(ServiceKakaoAPI, server.URL)).UnlinkUser(ctx, 1234); !errors.Is(err, ErrNoAdminKey) {
+		t.Errorf("expected ErrNoAdminKey, got: %v", err)
+	}
+}
@@ -0,0 +1,152 @@
+package kakaoapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"strconv"
+)
+
+// API names of vision APIs
+const (
+	APIVisionFace            APIName = "vision.face"
+	APIVisionProduct         APIName = "vision.product"
+	APIVisionMultitag        APIName = "vision.multitag"
+	APIVisionThumbnailDetect APIName = "vision.thumbnail.detect"
+	APIVisionThumbnailCrop   APIName = "vision.thumbnail.crop"
+	APIVisionAdult           APIName = "vision.adult"
+)
+
+// DetectFaces detects faces in given image.
+//
+// `threshold` is the minimum score of detected faces in [0.1, 1.0] (API default if 0).
+//
+// https://developers.kakao.com/docs/latest/ko/vision/dev-guide#recog-face
+func (c *Client) DetectFaces(ctx context.Context, image ImageSource, threshold float64) (res ResponseFaceDetection, err error) {
+	err = c.postVision(ctx, APIVisionFace, "/v2/vision/face/detect", image, visionThreshold(threshold), &res, "detecting faces")
+	return res, err
+}
+
+// DetectProducts detects products in given image.
+//
+// `threshold` is the minimum score of detected products in [0.1, 1.0] (API default if 0).
+//
+// https://developers.kakao.com/docs/latest/ko/vision/dev-guide#recog-product
+func (c *Client) DetectProducts(ctx context.Context, image ImageSource, threshold float64) (res ResponseProductDetection, err error) {
+	err = c.postVision(ctx, APIVisionProduct, "/v2/vision/product/detect", image, visionThreshold(threshold), &res, "detecting products")
+	return res, err
+}
+
+// GenerateTags generates tags which describe given image.
+//
+// https://developers.kakao.com/docs/latest/ko/vision/dev-guide#create-multi-tag
+func (c *Client) GenerateTags(ctx context.Context, image ImageSource) (res ResponseMultitag, err error) {
+	err = c.postVision(ctx, APIVisionMultitag, "/v2/vision/multitag/generate", image, nil, &res, "generating tags")
+	return res, err
+}
+
+// DetectThumbnail detects the key area of given image for a thumbnail with the ratio of `width`:`height`.
+//
+// https://developers.kakao.com/docs/latest/ko/vision/dev-guide#extract-thumbnail
+func (c *Client) DetectThumbnail(ctx context.Context, image ImageSource, width, height int) (res ResponseThumbnailDetection, err error) {
+	err = c.postVision(ctx, APIVisionThumbnailDetect, "/v2/vision/thumbnail/detect", image, thumbnailSize(width, height), &res, "detecting thumbnail")
+	return res, err
+}
+
+// CropThumbnail crops a thumbnail of `width` x `height` pixels from the key area of given image.
+//
+// https://developers.kakao.com/docs/latest/ko/vision/dev-guide#create-thumbnail
+func (c *Client) CropThumbnail(ctx context.Context, image ImageSource, width, height int) (res ResponseThumbnailCrop, err error) {
+	err = c.postVision(ctx, APIVisionThumbnailCrop, "/v2/vision/thumbnail/crop", image, thumbnailSize(width, height), &res, "cropping thumbnail")
+	return res, err
+}
+
+// DetectAdult detects adult content in given image.
+//
+// https://developers.kakao.com/docs/latest/ko/vision/dev-guide#recog-adult-content
+func (c *Client) DetectAdult(ctx context.Context, image ImageSource) (res ResponseAdultDetection, err error) {
+	err = c.postVision(ctx, APIVisionAdult, "/v2/vision/adult/detect", image, nil, &res, "detecting adult content")
+	return res, err
+}
+
+// optional `threshold` parameter
+func visionThreshold(threshold float64) map[string]any {
+	if threshold == 0 {
+		return nil
+	}
+
+	return map[string]any{"threshold": threshold}
+}
+
+// `width` and `height` parameters of thumbnails
+func thumbnailSize(width, height int) map[string]any {
+	return map[string]any{"width": width, "height": height}
+}
+
+// posts given image with params to a vision API, and decodes the response into `res`
+//
+// The image is sent as a form value if it is a URL, or as a multipart file otherwise.
+func (c *Client) postVision(ctx context.Context, api APIName, path string, image ImageSource, params map[string]any, res any, action string) (err error) {
+	if err = image.validate(params); err != nil {
+		return err
+	}
+
+	var body any
+	if file := image.fileParam(); file != nil {
+		multipart := map[string]any{"image": file}
+		for key, value := range params {
+			multipart[key] = value
+		}
+		body = multipart
+	} else {
+		form := url.Values{"image_url": {image.url}}
+		for key, value := range params {
+			switch v := value.(type) {
+			case float64:
+				form.Set(key, strconv.FormatFloat(v, 'f', -1, 64))
+			case int:
+				form.Set(key, strconv.Itoa(v))
+			}
+		}
+		body = form
+	}
+
+	var bytes []byte
+	bytes, err = c.post(ctx, api, c.baseURL(ServiceDapi)+path, authTypeKakaoAK, nil, body)
+
+	if err == nil {
+		err = json.Unmarshal(bytes, res)
+		if err != nil && c.Verbose {
+			c.logger.Error("failed to decode bytes while "+action, "bytes", c.redact(string(bytes)))
+		}
+	}
+
+	return err
+}
+
+// vision parameter ranges
+//
+// https://developers.kakao.com/docs/latest/ko/vision/dev-guide
+const (
+	visionMinThreshold = 0.1
+	visionMaxThreshold = 1.0
+)
+
+// validates the image source with the params of a vision API
+func (s ImageSource) validate(params map[string]any) error {
+	v := &validator{}
+
+	if len(s.url) <= 0 && s.bytes == nil && s.reader == nil {
+		v.fail("image", "one of image and image_url is required")
+	}
+	if threshold, ok := params["threshold"].(float64); ok {
+		v.checkFloatRange("threshold", &threshold, visionMinThreshold, visionMaxThreshold)
+	}
+	for _, field := range []string{"width", "height"} {
+		if size, ok := params[field].(int); ok && size <= 0 {
+			v.fail(field, "%d is not positive", size)
+		}
+	}
+
+	return v.err()
+}
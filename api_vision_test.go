@@ -0,0 +1,128 @@
+package kakaoapi
+
+import (
+	"context"
+	"errors"
+	"image"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestVisionAPIs(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if authorization := r.Header.Get("Authorization"); authorization != "KakaoAK test-api-key" {
+			t.Errorf("unexpected authorization: %s", authorization)
+		}
+
+		switch r.URL.Path {
+		case "/v2/vision/face/detect":
+			// image is sent as a multipart file
+			if err := r.ParseMultipartForm(1 << 20); err != nil {
+				t.Errorf("failed to parse multipart form: %s", err)
+			} else if file, _, err := r.FormFile("image"); err != nil {
+				t.Errorf("no image in multipart form: %s", err)
+			} else if bytes, _ := io.ReadAll(file); string(bytes) != "image bytes" {
+				t.Errorf("unexpected image: %s", bytes)
+			}
+			if threshold := r.FormValue("threshold"); threshold != "0.5" {
+				t.Errorf("unexpected threshold: %s", threshold)
+			}
+			w.Write([]byte(`{"rid":"face","result":{"width":200,"height":100,"faces":[{"x":0.25,"y":0.1,"w":0.5,"h":0.6,"score":0.9,"facial_attributes":{"gender":{"male":0.8,"female":0.2},"age":31.2},"facial_points":{"nose":[[0.5,0.4],[0.5,0.5]]}}]}}`))
+		case "/v2/vision/product/detect":
+			// image is sent as a URL
+			r.ParseForm()
+			if r.PostForm.Get("image_url") != "https://example.com/image.jpg" || r.PostForm.Has("threshold") {
+				t.Errorf("unexpected form: %v", r.PostForm)
+			}
+			w.Write([]byte(`{"rid":"product","result":{"width":200,"height":100,"objects":[{"x1":0.1,"y1":0.2,"x2":0.6,"y2":0.9,"class":"bag"}]}}`))
+		case "/v2/vision/thumbnail/detect":
+			r.ParseForm()
+			if r.PostForm.Get("width") != "3" || r.PostForm.Get("height") != "2" {
+				t.Errorf("unexpected form: %v", r.PostForm)
+			}
+			w.Write([]byte(`{"rid":"thumbnail","result":{"width":400,"height":200,"thumbnail":{"x":100,"y":50,"width":200,"height":100}}}`))
+		case "/v2/vision/multitag/generate":
+			if file, _, err := r.FormFile("image"); err != nil {
+				t.Errorf("no image in multipart form: %s", err)
+			} else if bytes, _ := io.ReadAll(file); string(bytes) != "image bytes" {
+				t.Errorf("unexpected image: %s", bytes)
+			}
+			w.Write([]byte(`{"rid":"multitag","result":{"label":["cat"],"label_kr":["고양이"]}}`))
+		case "/v2/vision/adult/detect":
+			w.Write([]byte(`{"rid":"adult","result":{"normal":0.9,"soft":0.07,"adult":0.03}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(ServiceDapi, server.URL))
+	ctx := context.Background()
+
+	faces, err := client.DetectFaces(ctx, NewImageSourceFromBytes([]byte("image bytes")), 0.5)
+	if err != nil {
+		t.Fatalf("failed to detect faces: %s", err)
+	}
+	if len(faces.Result.Faces) != 1 || faces.Result.Faces[0].FacialAttributes.Age != 31.2 || len(faces.Result.Faces[0].FacialPoints.Nose) != 2 {
+		t.Errorf("unexpected faces: %+v", faces)
+	} else if rect := faces.Result.Faces[0].Box().Rect(faces.Result.Width, faces.Result.Height); rect != image.Rect(50, 10, 150, 70) {
+		t.Errorf("unexpected face rect: %v", rect)
+	}
+
+	if products, err := client.DetectProducts(ctx, NewImageSourceFromURL("https://example.com/image.jpg"), 0); err != nil {
+		t.Errorf("failed to detect products: %s", err)
+	} else if box := products.Result.Objects[0].Box(); products.Result.Objects[0].Class != "bag" || box.X != 0.1 || box.Width != 0.5 {
+		t.Errorf("unexpected products: %+v", products)
+	}
+
+	if thumbnail, err := client.DetectThumbnail(ctx, NewImageSourceFromURL("https://example.com/image.jpg"), 3, 2); err != nil {
+		t.Errorf("failed to detect thumbnail: %s", err)
+	} else if box := thumbnail.Box(); box != (BoundingBox{X: 0.25, Y: 0.25, Width: 0.5, Height: 0.5}) {
+		t.Errorf("unexpected thumbnail box: %+v", box)
+	}
+
+	if adult, err := client.DetectAdult(ctx, NewImageSourceFromReader(strings.NewReader("image bytes"))); err != nil {
+		t.Errorf("failed to detect adult content: %s", err)
+	} else if adult.Result.Normal != 0.9 {
+		t.Errorf("unexpected result: %+v", adult)
+	}
+
+	// byte sources can be shared among concurrent requests
+	shared := NewImageSourceFromBytes([]byte("image bytes"))
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := client.GenerateTags(ctx, shared); err != nil {
+				t.Errorf("failed to generate tags: %s", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	// invalid params are not sent
+	for name, call := range map[string]func() error{
+		"no image": func() error {
+			_, err := client.GenerateTags(ctx, ImageSource{})
+			return err
+		},
+		"invalid threshold": func() error {
+			_, err := client.DetectFaces(ctx, NewImageSourceFromURL("https://example.com/image.jpg"), 1.5)
+			return err
+		},
+		"invalid width": func() error {
+			_, err := client.CropThumbnail(ctx, NewImageSourceFromURL("https://example.com/image.jpg"), 0, 100)
+			return err
+		},
+	} {
+		var validationErr *ValidationError
+		if err := call(); !errors.As(err, &validationErr) {
+			t.Errorf("should have failed validation with %s: %v", name, err)
+		}
+	}
+}
@@ -0,0 +1,429 @@
+// Package batch runs large numbers of KoGPT/Karlo requests from JSONL files,
+// with bounded concurrency and resumable checkpoints.
+//
+// Each line of the input file is a JSON object of `kakaoapi.ParamsImageGeneration`
+// or `kakaoapi.ParamsTextGeneration` (distinguished by the existence of `max_tokens`),
+// with an optional `id` field:
+//
+//	{"id": "cat-1", "prompt": "A cat with white fur", "samples": 2}
+//	{"id": "sky-1", "prompt": "오늘 아침 하늘은", "max_tokens": 64}
+//
+// Requests are throttled with the client's rate limiter (see `kakaoapi.WithRateLimiter`).
+package batch
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	kakaoapi "github.com/meinside/kakao-api-go"
+)
+
+// JobType is the type of a job
+type JobType string
+
+// JobTypes
+const (
+	JobTypeImage JobType = "image"
+	JobTypeText  JobType = "text"
+)
+
+// Config is the configuration of a batch run
+type Config struct {
+	InputPath      string // path of the input JSONL file
+	ResultsPath    string // path of the JSONL file for successful results (appended)
+	FailuresPath   string // path of the JSONL file for failures (appended)
+	CheckpointPath string // path of the checkpoint file, which lists IDs of finished jobs (appended)
+
+	Concurrency int // number of concurrent jobs (1 if <= 0)
+}
+
+// Summary is the summary of a batch run
+type Summary struct {
+	Total     int           `json:"total"`     // number of jobs in the input file
+	Succeeded int           `json:"succeeded"` // number of jobs succeeded in this run
+	Failed    int           `json:"failed"`    // number of jobs failed in this run
+	Skipped   int           `json:"skipped"`   // number of jobs skipped for they were finished in previous runs
+	Elapsed   time.Duration `json:"elapsed"`
+}
+
+// String returns the human-readable representation of Summary.
+func (s Summary) String() string {
+	return fmt.Sprintf("total: %d, succeeded: %d, failed: %d, skipped: %d, elapsed: %s", s.Total, s.Succeeded, s.Failed, s.Skipped, s.Elapsed)
+}
+
+// Result is a line of the results file
+type Result struct {
+	ID       string          `json:"id"`
+	Type     JobType         `json:"type"`
+	Response json.RawMessage `json:"response"`
+	Elapsed  time.Duration   `json:"elapsed"`
+}
+
+// Failure is a line of the failures file
+type Failure struct {
+	ID         string  `json:"id"`
+	Type       JobType `json:"type,omitempty"`
+	Line       int     `json:"line"`
+	Error      string  `json:"error"`
+	StatusCode int     `json:"status_code,omitempty"` // HTTP status (if it was an API error)
+	Code       int     `json:"code,omitempty"`        // Kakao error code (if it was an API error)
+	Retryable  bool    `json:"retryable,omitempty"`   // whether the job will be run again on resume
+}
+
+// a job parsed from a line of the input file
+type job struct {
+	id   string
+	line int
+	typ  JobType
+
+	image kakaoapi.ParamsImageGeneration
+	text  kakaoapi.ParamsTextGeneration
+
+	err error // parse error
+}
+
+// Run runs the jobs in the input file with given client, and returns the summary.
+//
+// Jobs listed in the checkpoint file are skipped, so an interrupted run can be resumed
+// by running again with the same config. Jobs are recorded to the checkpoint file
+// after their results (or failures) are written, so a crash may lead to duplicated outputs,
+// but never to lost ones.
+//
+// Only succeeded jobs and permanently failed ones (eg. with parse or validation errors) are recorded
+// to the checkpoint file, so jobs failed with transient errors (eg. HTTP 429/5xx, network errors)
+// are run again on resume.
+func Run(ctx context.Context, client *kakaoapi.Client, config Config) (summary Summary, err error) {
+	started := time.Now()
+	defer func() {
+		summary.Elapsed = time.Since(started)
+	}()
+
+	var jobs []job
+	if jobs, err = readJobs(config.InputPath); err != nil {
+		return summary, err
+	}
+	summary.Total = len(jobs)
+
+	var finished map[string]bool
+	if finished, err = readCheckpoint(config.CheckpointPath); err != nil {
+		return summary, err
+	}
+
+	var w *writer
+	if w, err = newWriter(config); err != nil {
+		return summary, err
+	}
+	defer func() {
+		if closeErr := w.close(); err == nil {
+			err = closeErr
+		}
+	}()
+
+	concurrency := config.Concurrency
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+
+	queue := make(chan job)
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var writeErr error
+
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for j := range queue {
+				result, failure := runJob(ctx, client, j)
+
+				// (jobs canceled by the context are not finished, so they will be run again on resume)
+				if failure != nil && ctx.Err() != nil {
+					continue
+				}
+
+				err := w.write(j.id, result, failure)
+
+				mu.Lock()
+				if err != nil && writeErr == nil {
+					writeErr = err
+				}
+				if failure != nil {
+					summary.Failed++
+				} else {
+					summary.Succeeded++
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+feed:
+	for _, j := range jobs {
+		if finished[j.id] {
+			summary.Skipped++
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			break feed
+		case queue <- j:
+		}
+	}
+	close(queue)
+	wg.Wait()
+
+	if writeErr != nil {
+		return summary, writeErr
+	}
+
+	return summary, ctx.Err()
+}
+
+// runs given job and returns its result or failure
+func runJob(ctx context.Context, client *kakaoapi.Client, j job) (*Result, *Failure) {
+	if j.err != nil {
+		return nil, newFailure(j, j.err)
+	}
+
+	started := time.Now()
+
+	var response any
+	var err error
+	switch j.typ {
+	case JobTypeText:
+		response, err = client.GenerateTextsWithContext(ctx, j.text)
+	default:
+		response, err = client.GenerateImagesWithContext(ctx, j.image)
+	}
+	if err != nil {
+		return nil, newFailure(j, err)
+	}
+
+	var marshalled []byte
+	if marshalled, err = json.Marshal(response); err != nil {
+		return nil, newFailure(j, err)
+	}
+
+	return &Result{
+		ID:       j.id,
+		Type:     j.typ,
+		Response: marshalled,
+		Elapsed:  time.Since(started),
+	}, nil
+}
+
+// returns a new failure of given job
+func newFailure(j job, err error) *Failure {
+	failure := &Failure{
+		ID:    j.id,
+		Type:  j.typ,
+		Line:  j.line,
+		Error: err.Error(),
+	}
+	if apiErr := kakaoapi.AsAPIError(err); apiErr != nil {
+		failure.StatusCode = apiErr.StatusCode
+		failure.Code = apiErr.Code
+	}
+	failure.Retryable = j.err == nil && isRetryable(err)
+
+	return failure
+}
+
+// checks if given error of a job is transient, so the job should be run again on resume
+func isRetryable(err error) bool {
+	var validationErr *kakaoapi.ValidationError
+	var ctxErr *kakaoapi.ContextError
+	if errors.As(err, &validationErr) {
+		return false
+	} else if errors.As(err, &ctxErr) {
+		return true
+	}
+
+	if apiErr := kakaoapi.AsAPIError(err); apiErr != nil {
+		switch apiErr.Code {
+		case kakaoapi.ErrorCodeInternal, kakaoapi.ErrorCodeServiceMaintenance, kakaoapi.ErrorCodeQuotaExceeded:
+			return true
+		}
+		return apiErr.StatusCode == http.StatusTooManyRequests || apiErr.StatusCode >= http.StatusInternalServerError
+	}
+
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	var unsupportedErr *json.UnsupportedValueError
+	if errors.As(err, &syntaxErr) || errors.As(err, &typeErr) || errors.As(err, &unsupportedErr) {
+		return false
+	}
+
+	return true // (network errors)
+}
+
+// reads jobs from the input file
+func readJobs(path string) (jobs []job, err error) {
+	var f *os.File
+	if f, err = os.Open(path); err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	ids := map[string]bool{}
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024) // (lines may contain base64-encoded images)
+	for line := 1; scanner.Scan(); line++ {
+		bytes := bytes.TrimSpace(scanner.Bytes())
+		if len(bytes) <= 0 {
+			continue
+		}
+
+		j := parseJob(line, bytes)
+		if ids[j.id] {
+			return nil, fmt.Errorf("duplicated job id '%s' at line %d", j.id, line)
+		}
+		ids[j.id] = true
+
+		jobs = append(jobs, j)
+	}
+
+	return jobs, scanner.Err()
+}
+
+// parses a job from given line
+func parseJob(line int, bytes []byte) (j job) {
+	j = job{
+		id:   fmt.Sprintf("line-%d", line),
+		line: line,
+		typ:  JobTypeImage,
+	}
+
+	var fields map[string]json.RawMessage
+	if j.err = json.Unmarshal(bytes, &fields); j.err != nil {
+		return j
+	}
+
+	if id, exists := fields["id"]; exists {
+		if j.err = json.Unmarshal(id, &j.id); j.err != nil {
+			return j
+		}
+	}
+
+	if _, exists := fields["max_tokens"]; exists {
+		j.typ = JobTypeText
+		j.err = json.Unmarshal(bytes, &j.text)
+	} else {
+		j.err = json.Unmarshal(bytes, &j.image)
+	}
+
+	return j
+}
+
+// reads IDs of finished jobs from the checkpoint file
+func readCheckpoint(path string) (map[string]bool, error) {
+	finished := map[string]bool{}
+
+	bytes, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return finished, nil
+		}
+		return nil, err
+	}
+
+	for _, id := range strings.Split(string(bytes), "\n") {
+		if id = strings.TrimSpace(id); len(id) > 0 {
+			finished[id] = true
+		}
+	}
+
+	return finished, nil
+}
+
+// writer of results, failures, and checkpoints
+type writer struct {
+	mu sync.Mutex
+
+	results    *os.File
+	failures   *os.File
+	checkpoint *os.File
+}
+
+// opens output files of given config for appending
+func newWriter(config Config) (w *writer, err error) {
+	w = &writer{}
+	for _, output := range []struct {
+		path string
+		file **os.File
+	}{
+		{config.ResultsPath, &w.results},
+		{config.FailuresPath, &w.failures},
+		{config.CheckpointPath, &w.checkpoint},
+	} {
+		if len(output.path) <= 0 {
+			err = fmt.Errorf("paths of results, failures, and checkpoint files are needed")
+		} else {
+			*output.file, err = os.OpenFile(output.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+		}
+		if err != nil {
+			w.close()
+			return nil, err
+		}
+	}
+
+	return w, nil
+}
+
+// writes given result or failure, then records the job to the checkpoint
+// (unless it failed with a retryable error)
+func (w *writer) write(id string, result *Result, failure *Failure) (err error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if result != nil {
+		err = writeLine(w.results, result)
+	} else {
+		err = writeLine(w.failures, failure)
+	}
+
+	if err == nil && (failure == nil || !failure.Retryable) {
+		if _, err = fmt.Fprintln(w.checkpoint, id); err == nil {
+			err = w.checkpoint.Sync()
+		}
+	}
+
+	return err
+}
+
+// closes all output files
+func (w *writer) close() (err error) {
+	for _, f := range []*os.File{w.results, w.failures, w.checkpoint} {
+		if f != nil {
+			if closeErr := f.Close(); err == nil {
+				err = closeErr
+			}
+		}
+	}
+
+	return err
+}
+
+// writes given value as a JSON line and syncs the file
+func writeLine(f *os.File, v any) error {
+	bytes, err := json.Marshal(v)
+	if err == nil {
+		if _, err = f.Write(append(bytes, '\n')); err == nil {
+			err = f.Sync()
+		}
+	}
+
+	return err
+}
@@ -0,0 +1,144 @@
+package batch
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	kakaoapi "github.com/meinside/kakao-api-go"
+	"github.com/meinside/kakao-api-go/kakaoapitest"
+)
+
+// reads lines of given JSONL file
+func readLines(t *testing.T, path string) (lines []map[string]any) {
+	t.Helper()
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("failed to open %s: %s", path, err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var line map[string]any
+		if err := json.Unmarshal(scanner.Bytes(), &line); err != nil {
+			t.Fatalf("failed to decode line of %s: %s", path, err)
+		}
+		lines = append(lines, line)
+	}
+
+	return lines
+}
+
+func TestRun(t *testing.T) {
+	server := kakaoapitest.NewServer()
+	defer server.Close()
+
+	client := kakaoapi.NewClient(server.APIKey,
+		kakaoapi.WithBaseURL(kakaoapi.ServiceKoGPT, server.KoGPTURL()),
+		kakaoapi.WithBaseURL(kakaoapi.ServiceKarlo, server.KarloURL()),
+	)
+
+	dir := t.TempDir()
+	config := Config{
+		InputPath:      filepath.Join(dir, "input.jsonl"),
+		ResultsPath:    filepath.Join(dir, "results.jsonl"),
+		FailuresPath:   filepath.Join(dir, "failures.jsonl"),
+		CheckpointPath: filepath.Join(dir, "checkpoint"),
+		Concurrency:    3,
+	}
+	os.WriteFile(config.InputPath, []byte(strings.Join([]string{
+		`{"id": "cat-1", "prompt": "A cat with white fur", "samples": 2}`,
+		`{"id": "cat-2", "prompt": "A cat with black fur"}`,
+		`{"id": "sky-1", "prompt": "오늘 아침 하늘은", "max_tokens": 16}`,
+		``,
+		`{"prompt": "A dog", "samples": 9}`,
+		`not a json`,
+	}, "\n")), 0644)
+
+	// first run: one request fails with a server error
+	server.FailNext(kakaoapitest.EndpointKarloT2I, 1, http.StatusInternalServerError, kakaoapi.ErrorCodeInternal, "internal error")
+	summary, err := Run(context.Background(), client, config)
+	if err != nil {
+		t.Fatalf("failed to run batch: %s", err)
+	}
+	if summary.Total != 5 || summary.Succeeded != 2 || summary.Failed != 3 || summary.Skipped != 0 {
+		t.Errorf("unexpected summary: %s", summary)
+	}
+
+	results := readLines(t, config.ResultsPath)
+	failures := readLines(t, config.FailuresPath)
+	if len(results) != 2 || len(failures) != 3 {
+		t.Errorf("unexpected count of results (%d) and failures (%d)", len(results), len(failures))
+	}
+	for _, failure := range failures {
+		switch failure["id"] {
+		case "line-5", "line-6": // invalid samples, invalid json
+			if failure["retryable"] != nil {
+				t.Errorf("failure should not be retryable: %v", failure)
+			}
+		default:
+			if failure["status_code"] != float64(http.StatusInternalServerError) || failure["retryable"] != true {
+				t.Errorf("unexpected failure: %v", failure)
+			}
+		}
+	}
+
+	// second run: the job failed with the server error is run again
+	summary, err = Run(context.Background(), client, config)
+	if err != nil {
+		t.Fatalf("failed to resume batch: %s", err)
+	}
+	if summary.Skipped != 4 || summary.Succeeded != 1 || summary.Failed != 0 {
+		t.Errorf("unexpected summary of resumed run: %s", summary)
+	}
+
+	// third run: all jobs are skipped
+	summary, err = Run(context.Background(), client, config)
+	if err != nil {
+		t.Fatalf("failed to resume batch: %s", err)
+	}
+	if summary.Skipped != 5 || summary.Succeeded != 0 || summary.Failed != 0 {
+		t.Errorf("unexpected summary of resumed run: %s", summary)
+	}
+	server.AssertCalled(t, kakaoapitest.EndpointKarloT2I, 3)
+	server.AssertCalled(t, kakaoapitest.EndpointKoGPTGeneration, 1)
+}
+
+func TestRunCanceled(t *testing.T) {
+	server := kakaoapitest.NewServer()
+	defer server.Close()
+
+	client := kakaoapi.NewClient(server.APIKey, kakaoapi.WithBaseURL(kakaoapi.ServiceKarlo, server.KarloURL()))
+
+	dir := t.TempDir()
+	config := Config{
+		InputPath:      filepath.Join(dir, "input.jsonl"),
+		ResultsPath:    filepath.Join(dir, "results.jsonl"),
+		FailuresPath:   filepath.Join(dir, "failures.jsonl"),
+		CheckpointPath: filepath.Join(dir, "checkpoint"),
+	}
+	os.WriteFile(config.InputPath, []byte(`{"prompt": "A cat"}`+"\n"+`{"prompt": "A dog"}`), 0644)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := Run(ctx, client, config); err == nil {
+		t.Errorf("should fail with canceled context")
+	}
+
+	// canceled jobs are run on resume
+	summary, err := Run(context.Background(), client, config)
+	if err != nil {
+		t.Fatalf("failed to resume batch: %s", err)
+	}
+	if summary.Succeeded != 2 {
+		t.Errorf("unexpected summary of resumed run: %s", summary)
+	}
+}
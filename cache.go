@@ -0,0 +1,333 @@
+package kakaoapi
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CacheHeader is the header set on responses served from the cache
+const CacheHeader = "X-Kakao-Api-Go-Cache"
+
+// error for calls which cannot be keyed for the cache
+var errUncacheableParams = errors.New("params with files are not cacheable")
+
+// Cache stores response bodies of deterministic API calls
+//
+// Implementations must be safe for concurrent use.
+type Cache interface {
+	// Get returns the cached value for given key, or false if it is missing or expired.
+	Get(key string) ([]byte, bool)
+
+	// Set stores the value for given key, which expires after `ttl` (never expires if `ttl` <= 0).
+	Set(key string, value []byte, ttl time.Duration)
+}
+
+// WithCache sets the cache for responses of deterministic API calls
+// (eg. seeded image generations, upscales, NSFW checks) with given TTL.
+//
+// NOTE: image URLs in cached responses expire on Kakao's side, so use a shorter TTL
+// or `ImageReturnBase64` for long-lived caches.
+func WithCache(cache Cache, ttl time.Duration) ClientOption {
+	return func(c *Client) {
+		c.cache = cache
+		c.cacheTTL = ttl
+	}
+}
+
+// returns the RoundTrip which serves deterministic calls from the cache
+func (c *Client) cached(next RoundTrip) RoundTrip {
+	return func(ctx context.Context, call *Call) (*Response, error) {
+		if c.cache == nil || !call.Deterministic {
+			return next(ctx, call)
+		}
+
+		key, err := cacheKey(call)
+		if err != nil { // (eg. file params)
+			return next(ctx, call)
+		}
+
+		if body, ok := c.cache.Get(key); ok {
+			return &Response{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{CacheHeader: []string{"hit"}},
+				Body:       body,
+			}, nil
+		}
+
+		resp, err := next(ctx, call)
+		if err == nil && resp != nil && resp.StatusCode == http.StatusOK {
+			c.cache.Set(key, resp.Body, c.cacheTTL)
+		}
+
+		return resp, err
+	}
+}
+
+// returns the canonical hash of given call's API, URL and params
+//
+// (maps are marshalled with sorted keys, so equal params always produce the same key)
+//
+// Calls with file params are not cacheable, for their contents are not marshalled
+// and different files would produce the same key.
+func cacheKey(call *Call) (string, error) {
+	if params, ok := call.Params.(map[string]any); ok && hasFileInParams(params) {
+		return "", errUncacheableParams
+	}
+
+	params, err := json.Marshal(call.Params)
+	if err != nil {
+		return "", err
+	}
+
+	hash := sha256.New()
+	hash.Write([]byte(call.API))
+	hash.Write([]byte{0})
+	hash.Write([]byte(call.Method + " " + call.URL))
+	hash.Write([]byte{0})
+	hash.Write(params)
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+///////////////////////////////
+//
+// in-memory cache
+
+// MemoryCache is an in-memory LRU cache
+type MemoryCache struct {
+	mu         sync.Mutex
+	maxEntries int
+	entries    *list.List
+	index      map[string]*list.Element
+}
+
+type memoryCacheEntry struct {
+	key     string
+	value   []byte
+	expires time.Time // zero if never expires
+}
+
+// NewMemoryCache returns a new MemoryCache which holds at most `maxEntries` entries.
+//
+// The number of entries is not limited if `maxEntries` <= 0.
+func NewMemoryCache(maxEntries int) *MemoryCache {
+	return &MemoryCache{
+		maxEntries: maxEntries,
+		entries:    list.New(),
+		index:      map[string]*list.Element{},
+	}
+}
+
+// Get returns the cached value for given key.
+func (m *MemoryCache) Get(key string) ([]byte, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if elem, exists := m.index[key]; exists {
+		entry := elem.Value.(*memoryCacheEntry)
+		if !entry.expires.IsZero() && time.Now().After(entry.expires) {
+			m.remove(elem)
+			return nil, false
+		}
+
+		m.entries.MoveToFront(elem)
+		return entry.value, true
+	}
+
+	return nil, false
+}
+
+// Set stores the value for given key, evicting the least recently used entries if needed.
+func (m *MemoryCache) Set(key string, value []byte, ttl time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var expires time.Time
+	if ttl > 0 {
+		expires = time.Now().Add(ttl)
+	}
+
+	if elem, exists := m.index[key]; exists {
+		entry := elem.Value.(*memoryCacheEntry)
+		entry.value, entry.expires = value, expires
+		m.entries.MoveToFront(elem)
+		return
+	}
+
+	m.index[key] = m.entries.PushFront(&memoryCacheEntry{
+		key:     key,
+		value:   value,
+		expires: expires,
+	})
+	for m.maxEntries > 0 && m.entries.Len() > m.maxEntries {
+		m.remove(m.entries.Back())
+	}
+}
+
+// Len returns the number of entries in the cache, including expired ones not evicted yet.
+func (m *MemoryCache) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.entries.Len()
+}
+
+// removes given element (must be called with the lock held)
+func (m *MemoryCache) remove(elem *list.Element) {
+	m.entries.Remove(elem)
+	delete(m.index, elem.Value.(*memoryCacheEntry).key)
+}
+
+///////////////////////////////
+//
+// filesystem cache
+
+// FileCache is a cache which stores entries as files in a directory
+//
+// Expiry times are kept in the files' modification times.
+type FileCache struct {
+	mu       sync.Mutex
+	dir      string
+	maxBytes int64
+}
+
+// file extension of cache entries
+const fileCacheExt = ".cache"
+
+// NewFileCache returns a new FileCache in given directory (created if missing),
+// which holds at most `maxBytes` bytes of entries.
+//
+// The total size is not limited if `maxBytes` <= 0.
+func NewFileCache(dir string, maxBytes int64) (*FileCache, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+
+	return &FileCache{
+		dir:      dir,
+		maxBytes: maxBytes,
+	}, nil
+}
+
+// Get returns the cached value for given key.
+func (f *FileCache) Get(key string) ([]byte, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	path := f.path(key)
+	if info, err := os.Stat(path); err == nil {
+		if time.Now().After(info.ModTime()) {
+			_ = os.Remove(path)
+			return nil, false
+		}
+
+		if value, err := os.ReadFile(path); err == nil {
+			return value, true
+		}
+	}
+
+	return nil, false
+}
+
+// Set stores the value for given key, evicting the entries closest to expiry if the size limit is exceeded.
+//
+// Failures to write are ignored, as the cache is only an optimization.
+func (f *FileCache) Set(key string, value []byte, ttl time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	expires := time.Now().AddDate(100, 0, 0) // (never expires)
+	if ttl > 0 {
+		expires = time.Now().Add(ttl)
+	}
+
+	// write to a temporary file first, so that readers never see partial entries
+	path := f.path(key)
+	tmp, err := os.CreateTemp(f.dir, "tmp-*")
+	if err != nil {
+		return
+	}
+	_, err = tmp.Write(value)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Chtimes(tmp.Name(), expires, expires)
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), path)
+	}
+	if err != nil {
+		_ = os.Remove(tmp.Name())
+		return
+	}
+
+	f.evict()
+}
+
+// removes expired entries, then the entries closest to expiry until the total size fits (must be called with the lock held)
+func (f *FileCache) evict() {
+	entries, err := os.ReadDir(f.dir)
+	if err != nil {
+		return
+	}
+
+	type file struct {
+		path    string
+		size    int64
+		expires time.Time
+	}
+	var files []file
+	var total int64
+	now := time.Now()
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), fileCacheExt) {
+			continue
+		}
+		if info, err := entry.Info(); err == nil {
+			path := filepath.Join(f.dir, entry.Name())
+			if now.After(info.ModTime()) {
+				_ = os.Remove(path)
+				continue
+			}
+
+			files = append(files, file{path: path, size: info.Size(), expires: info.ModTime()})
+			total += info.Size()
+		}
+	}
+
+	if f.maxBytes <= 0 || total <= f.maxBytes {
+		return
+	}
+
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].expires.Before(files[j].expires)
+	})
+	for _, file := range files {
+		if total <= f.maxBytes {
+			break
+		}
+		if err := os.Remove(file.path); err == nil {
+			total -= file.size
+		}
+	}
+}
+
+// returns the path of the file for given key
+//
+// (keys are hashed, so that any key is a safe file name)
+func (f *FileCache) path(key string) string {
+	hash := sha256.Sum256([]byte(key))
+	return filepath.Join(f.dir, hex.EncodeToString(hash[:])+fileCacheExt)
+}
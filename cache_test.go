@@ -0,0 +1,182 @@
+package kakaoapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCacheDeterministicCalls(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.Write([]byte(`{"id":"generated","images":[{"id":"image","seed":42}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key",
+		WithBaseURL(ServiceKarlo, server.URL),
+		WithCache(NewMemoryCache(10), time.Minute),
+	)
+
+	// seeded generations are cached
+	seeded := NewParamsImageGeneration("a cat").SetSeed([]int{42})
+	for i := 0; i < 3; i++ {
+		if generated, err := client.GenerateImages(seeded); err != nil {
+			t.Fatalf("failed to generate images: %s", err)
+		} else if generated.ID != "generated" {
+			t.Errorf("unexpected response: %+v", generated)
+		}
+	}
+	if n := atomic.LoadInt32(&requests); n != 1 {
+		t.Errorf("expected 1 request for seeded generations, got %d", n)
+	}
+
+	// different params are not served from the cache
+	if _, err := client.GenerateImages(NewParamsImageGeneration("a dog").SetSeed([]int{42})); err != nil {
+		t.Fatalf("failed to generate images: %s", err)
+	}
+	if n := atomic.LoadInt32(&requests); n != 2 {
+		t.Errorf("expected 2 requests after different params, got %d", n)
+	}
+
+	// unseeded generations are never cached
+	for i := 0; i < 2; i++ {
+		if _, err := client.GenerateImages(NewParamsImageGeneration("a cat")); err != nil {
+			t.Fatalf("failed to generate images: %s", err)
+		}
+	}
+	if n := atomic.LoadInt32(&requests); n != 4 {
+		t.Errorf("expected 4 requests after unseeded generations, got %d", n)
+	}
+}
+
+func TestCacheSkipsFailures(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(`{"code":-1,"msg":"internal error"}`))
+			return
+		}
+		w.Write([]byte(`{"id":"checked","results":[]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key",
+		WithBaseURL(ServiceKarlo, server.URL),
+		WithCache(NewMemoryCache(10), time.Minute),
+	)
+
+	if _, err := client.CheckNSFW([]string{"image"}); err == nil {
+		t.Errorf("should have failed")
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := client.CheckNSFW([]string{"image"}); err != nil {
+			t.Errorf("failed to check NSFW: %s", err)
+		}
+	}
+	if n := atomic.LoadInt32(&requests); n != 2 {
+		t.Errorf("expected 2 requests (failure not cached), got %d", n)
+	}
+}
+
+func TestCacheKeyWithFiles(t *testing.T) {
+	call := func(image []byte) *Call {
+		return &Call{
+			API:           APIKarloUpscale,
+			Method:        http.MethodPost,
+			URL:           "https://example.com/upscale",
+			Params:        map[string]any{"image": newFileParamFromBytes(image)},
+			Deterministic: true,
+		}
+	}
+
+	// different files should never share a cached response
+	if _, err := cacheKey(call([]byte("image 1"))); err == nil {
+		t.Errorf("calls with file params should not be cacheable")
+	}
+
+	var requests int32
+	client := NewClient("test-api-key", WithCache(NewMemoryCache(10), time.Minute))
+	next := func(ctx context.Context, call *Call) (*Response, error) {
+		atomic.AddInt32(&requests, 1)
+		return &Response{StatusCode: http.StatusOK, Body: []byte("{}")}, nil
+	}
+	for _, image := range []string{"image 1", "image 2"} {
+		if resp, err := client.cached(next)(context.Background(), call([]byte(image))); err != nil {
+			t.Fatalf("failed to call: %s", err)
+		} else if resp.Header.Get(CacheHeader) != "" {
+			t.Errorf("should not have been served from the cache")
+		}
+	}
+	if n := atomic.LoadInt32(&requests); n != 2 {
+		t.Errorf("expected 2 requests, got %d", n)
+	}
+}
+
+func TestMemoryCache(t *testing.T) {
+	cache := NewMemoryCache(2)
+
+	cache.Set("a", []byte("1"), 0)
+	cache.Set("b", []byte("2"), 0)
+	cache.Get("a") // (b becomes the least recently used)
+	cache.Set("c", []byte("3"), 0)
+
+	if _, ok := cache.Get("b"); ok {
+		t.Errorf("least recently used entry should have been evicted")
+	}
+	if value, ok := cache.Get("a"); !ok || string(value) != "1" {
+		t.Errorf("unexpected value for a: %q, %t", value, ok)
+	}
+	if cache.Len() != 2 {
+		t.Errorf("expected 2 entries, got %d", cache.Len())
+	}
+
+	cache.Set("expiring", []byte("4"), time.Millisecond)
+	time.Sleep(5 * time.Millisecond)
+	if _, ok := cache.Get("expiring"); ok {
+		t.Errorf("expired entry should not be returned")
+	}
+}
+
+func TestFileCache(t *testing.T) {
+	dir := t.TempDir()
+
+	cache, err := NewFileCache(dir, 10)
+	if err != nil {
+		t.Fatalf("failed to create file cache: %s", err)
+	}
+
+	cache.Set("first", []byte("12345"), time.Minute)
+	cache.Set("second", []byte("67890"), time.Hour)
+	if value, ok := cache.Get("first"); !ok || string(value) != "12345" {
+		t.Errorf("unexpected value for first: %q, %t", value, ok)
+	}
+
+	// exceeding the size limit evicts the entry closest to expiry
+	cache.Set("third", []byte("abcde"), 2*time.Hour)
+	if _, ok := cache.Get("first"); ok {
+		t.Errorf("entry closest to expiry should have been evicted")
+	}
+	for _, key := range []string{"second", "third"} {
+		if _, ok := cache.Get(key); !ok {
+			t.Errorf("entry %s should exist", key)
+		}
+	}
+
+	// entries survive across instances
+	reopened, _ := NewFileCache(dir, 10)
+	if value, ok := reopened.Get("third"); !ok || string(value) != "abcde" {
+		t.Errorf("unexpected value for third after reopening: %q, %t", value, ok)
+	}
+
+	cache.Set("expiring", []byte("x"), time.Millisecond)
+	time.Sleep(5 * time.Millisecond)
+	if _, ok := cache.Get("expiring"); ok {
+		t.Errorf("expired entry should not be returned")
+	}
+}
@@ -2,6 +2,7 @@ package kakaoapi
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -11,6 +12,8 @@ import (
 	"net"
 	"net/http"
 	"net/http/httputil"
+	"net/textproto"
+	"net/url"
 	"strings"
 	"time"
 )
@@ -21,19 +24,41 @@ import (
 const (
 	APIBaseURLKoGPT = "https://api.kakaobrain.com/v1/inference/kogpt"
 	APIBaseURLKarlo = "https://api.kakaobrain.com/v2/inference/karlo"
+
+	APIBaseURLKakaoAuth = "https://kauth.kakao.com"
+	APIBaseURLKakao     = "https://kapi.kakao.com"
+	APIBaseURLDapi      = "https://dapi.kakao.com"
 )
 
 // Client struct
 type Client struct {
 	apiKey     string
+	adminKey   string
 	httpClient *http.Client
 
-	Verbose bool // log verbose message or not
+	baseURLs  map[Service]string
+	timeout   time.Duration
+	userAgent string
+	logger    Logger
+
+	middlewares []Middleware
+	limiter     *RateLimiter
+
+	cache    Cache
+	cacheTTL time.Duration
+
+	clientSecret string
+	tokenSource  TokenSource
+
+	promptTranslation *promptTranslation
+
+	Retry   *RetryPolicy // retry policy for failed requests (no retry if nil)
+	Verbose bool         // log verbose message or not
 }
 
-// NewClient returns a new API client
-func NewClient(apiKey string) *Client {
-	return &Client{
+// NewClient returns a new API client with given options
+func NewClient(apiKey string, opts ...ClientOption) *Client {
+	c := &Client{
 		apiKey: apiKey,
 		httpClient: &http.Client{
 			Transport: &http.Transport{
@@ -47,122 +72,328 @@ func NewClient(apiKey string) *Client {
 				ExpectContinueTimeout: 1 * time.Second,
 			},
 		},
+		baseURLs: map[Service]string{
+			ServiceKoGPT:     APIBaseURLKoGPT,
+			ServiceKarlo:     APIBaseURLKarlo,
+			ServiceKakaoAuth: APIBaseURLKakaoAuth,
+			ServiceKakaoAPI:  APIBaseURLKakao,
+			ServiceDapi:      APIBaseURLDapi,
+		},
+		logger:  NewStdLogger(log.Default(), LogLevelDebug),
 		Verbose: false,
 	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	// apply timeout to a copy of the HTTP client, not to the given one
+	if c.timeout > 0 {
+		httpClient := *c.httpClient
+		httpClient.Timeout = c.timeout
+		if transport, ok := httpClient.Transport.(*http.Transport); ok {
+			transport = transport.Clone()
+			transport.ResponseHeaderTimeout = c.timeout
+			httpClient.Transport = transport
+		}
+		c.httpClient = &httpClient
+	}
+
+	return c
+}
+
+// returns the base URL of given service
+func (c *Client) baseURL(service Service) string {
+	return c.baseURLs[service]
 }
 
 // HTTP functions
 
 // HTTP GET
-func (c *Client) get(apiURL string, authType authType, headers map[string]string, params map[string]any) ([]byte, error) {
-	var err error
-	var req *http.Request
-	if req, err = http.NewRequest("GET", apiURL, nil); err == nil {
-		// set HTTP headers
-		for k, v := range headers {
-			req.Header.Set(k, v)
-		}
-		req.Header.Set("Authorization", c.authHeader(authType)) // set auth header
+func (c *Client) get(ctx context.Context, api APIName, apiURL string, authType authType, headers map[string]string, params map[string]any) ([]byte, error) {
+	return c.call(ctx, &Call{
+		API:      api,
+		Method:   "GET",
+		URL:      apiURL,
+		Headers:  headers,
+		Params:   params,
+		authType: authType,
+	})
+}
 
-		// set parameters
-		queries := req.URL.Query()
-		for key, value := range params {
-			queries.Add(key, fmt.Sprintf("%v", value))
+// HTTP POST
+func (c *Client) post(ctx context.Context, api APIName, apiURL string, authType authType, headers map[string]string, params any) ([]byte, error) {
+	return c.call(ctx, &Call{
+		API:      api,
+		Method:   "POST",
+		URL:      apiURL,
+		Headers:  headers,
+		Params:   params,
+		authType: authType,
+	})
+}
+
+// HTTP POST whose response is determined by given params only (cached if the client has a cache)
+func (c *Client) postDeterministic(ctx context.Context, api APIName, apiURL string, authType authType, headers map[string]string, params any) ([]byte, error) {
+	return c.call(ctx, &Call{
+		API:           api,
+		Method:        "POST",
+		URL:           apiURL,
+		Headers:       headers,
+		Params:        params,
+		Deterministic: true,
+		authType:      authType,
+	})
+}
+
+// runs given call through the middlewares and returns the response body
+func (c *Client) call(ctx context.Context, call *Call) ([]byte, error) {
+	resp, err := c.chain()(ctx, call)
+	if resp == nil {
+		if err == nil {
+			err = fmt.Errorf("no response for %s %s", call.Method, call.URL)
 		}
-		req.URL.RawQuery = queries.Encode()
+		return []byte{}, err
+	}
 
-		return c.fetchHTTPResponse(req)
+	// (synthetic responses from middlewares may not have errors)
+	if err == nil && resp.StatusCode != http.StatusOK {
+		err = newAPIError(call.path(), resp.StatusCode, resp.Header, resp.Body)
 	}
 
-	return []byte{}, err
+	return resp.Body, err
 }
 
-// HTTP POST
-func (c *Client) post(apiURL string, authType authType, headers map[string]string, params map[string]any) ([]byte, error) {
-	var err error
-
-	if hasFileInParams(params) {
-		// multipart/form-data
-
-		body := &bytes.Buffer{}
-		writer := multipart.NewWriter(body)
-
-		for key, value := range params {
-			switch value.(type) {
-			case fileParam:
-				file, _ := value.(fileParam)
-				filename := fmt.Sprintf("%s.%s", key, getExtension(file.bytes))
-
-				if part, err := writer.CreateFormFile(key, filename); err == nil {
-					if _, err := io.Copy(part, bytes.NewReader(file.bytes)); err != nil {
-						log.Printf("* Could not write bytes to multipart for param '%s': %s", key, err)
-					}
-				} else {
-					log.Printf("* Could not create part for param '%s': %s", key, err)
-				}
-			default:
-				if bytes, err := json.Marshal(value); err == nil {
-					writer.WriteField(key, string(bytes))
-				} else {
-					writer.WriteField(key, fmt.Sprintf("%v", value))
-				}
+// sends given call over HTTP, the innermost RoundTrip of the middleware chain
+func (c *Client) send(ctx context.Context, call *Call) (*Response, error) {
+	// request is rebuilt on every attempt, so that it can be retried safely
+	return c.fetchHTTPResponse(ctx, call.API, func() (*http.Request, error) {
+		return c.newRequest(ctx, call)
+	})
+}
+
+// builds a new HTTP request of given call
+func (c *Client) newRequest(ctx context.Context, call *Call) (req *http.Request, err error) {
+	if call.Method == "GET" {
+		if req, err = http.NewRequestWithContext(ctx, call.Method, call.URL, nil); err == nil {
+			// set parameters
+			queries := req.URL.Query()
+			params, _ := call.Params.(map[string]any)
+			for key, value := range params {
+				queries.Add(key, fmt.Sprintf("%v", value))
+			}
+			req.URL.RawQuery = queries.Encode()
+		}
+	} else {
+		var body io.Reader
+		var contentType string
+
+		if form, ok := call.Params.(url.Values); ok {
+			// application/x-www-form-urlencoded
+			body, contentType = strings.NewReader(form.Encode()), "application/x-www-form-urlencoded;charset=utf-8"
+		} else if params, ok := call.Params.(map[string]any); ok && hasFileInParams(params) {
+			// multipart/form-data
+			var multipartBody io.ReadCloser
+			if multipartBody, contentType, err = c.multipartBody(params); err != nil {
+				return nil, err
 			}
+			body = multipartBody
+		} else {
+			// application/json
+			var marshalled []byte
+			if marshalled, err = json.Marshal(call.Params); err != nil {
+				return nil, err
+			}
+			body, contentType = bytes.NewBuffer(marshalled), "application/json; charset=utf-8"
 		}
 
-		if err := writer.Close(); err != nil {
-			log.Printf("* Error while closing multipart form data writer: %s", err)
+		if req, err = http.NewRequestWithContext(ctx, call.Method, call.URL, body); err == nil {
+			req.Header.Set("Content-Type", contentType)
+		} else if closer, ok := body.(io.Closer); ok {
+			closer.Close() // (stops the multipart writer)
 		}
+	}
 
-		var req *http.Request
-		if req, err = http.NewRequest("POST", apiURL, body); err == nil {
-			// set HTTP headers
-			for k, v := range headers {
-				req.Header.Set(k, v)
+	if err == nil {
+		// set HTTP headers
+		for k, v := range call.Headers {
+			req.Header.Set(k, v)
+		}
+
+		// set auth header
+		var authorization string
+		if authorization, err = c.authHeader(ctx, call.authType); err != nil {
+			if req.Body != nil {
+				req.Body.Close() // (stops the multipart writer)
 			}
-			req.Header.Set("Content-Type", writer.FormDataContentType())
-			req.Header.Set("Authorization", c.authHeader(authType)) // set auth header
+			return nil, err
+		} else if len(authorization) > 0 {
+			req.Header.Set("Authorization", authorization)
+		}
+	}
 
-			return c.fetchHTTPResponse(req)
+	return req, err
+}
+
+// streams a multipart/form-data body with given params through a pipe
+func (c *Client) multipartBody(params map[string]any) (body io.ReadCloser, contentType string, err error) {
+	reader, writer := io.Pipe()
+	multipartWriter := multipart.NewWriter(writer)
+
+	// stops the writer goroutine (by closing the pipe) and waits for it to return
+	done := make(chan struct{})
+	stop := func() {
+		reader.Close()
+		<-done
+	}
+
+	// rewind files for retries (after the writers of previous attempts are stopped)
+	for _, value := range params {
+		if file, ok := value.(*fileParam); ok {
+			if err = file.rewind(stop); err != nil {
+				close(done) // (the writer goroutine is not started yet)
+				reader.Close()
+				return nil, "", err
+			}
 		}
-	} else {
-		// application/json
+	}
 
-		// parameters
-		var body []byte
-		body, err = json.Marshal(params)
+	go func() {
+		defer close(done)
 
+		err := writeMultipart(multipartWriter, params)
 		if err == nil {
-			var req *http.Request
-			if req, err = http.NewRequest("POST", apiURL, bytes.NewBuffer(body)); err == nil {
-				// set HTTP headers
-				for k, v := range headers {
-					req.Header.Set(k, v)
-				}
-				req.Header.Set("Content-Type", "application/json; charset=utf-8")
-				req.Header.Set("Authorization", c.authHeader(authType)) // set auth header
+			err = multipartWriter.Close()
+		}
 
-				return c.fetchHTTPResponse(req)
+		// (errors are propagated to the reader, and fail the request)
+		writer.CloseWithError(err)
+	}()
+
+	return reader, multipartWriter.FormDataContentType(), nil
+}
+
+// writes given params to the multipart writer
+func writeMultipart(writer *multipart.Writer, params map[string]any) error {
+	for key, value := range params {
+		switch value.(type) {
+		case *fileParam:
+			file, _ := value.(*fileParam)
+
+			// detect content type from the first 512 bytes
+			head := make([]byte, 512)
+			n, err := io.ReadFull(file.reader, head)
+			if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+				return fmt.Errorf("could not read file parameter '%s': %w", key, err)
+			}
+			head = head[:n]
+
+			header := make(textproto.MIMEHeader)
+			header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="%s"; filename="%s.%s"`, key, key, getExtension(head)))
+			header.Set("Content-Type", http.DetectContentType(head))
+
+			var part io.Writer
+			if part, err = writer.CreatePart(header); err == nil {
+				_, err = io.Copy(part, io.MultiReader(bytes.NewReader(head), file.reader))
+			}
+			if err != nil {
+				return fmt.Errorf("could not write file parameter '%s' to multipart: %w", key, err)
+			}
+		default:
+			var err error
+			if bytes, marshalErr := json.Marshal(value); marshalErr == nil {
+				err = writer.WriteField(key, string(bytes))
+			} else {
+				err = writer.WriteField(key, fmt.Sprintf("%v", value))
+			}
+			if err != nil {
+				return fmt.Errorf("could not write parameter '%s' to multipart: %w", key, err)
 			}
 		}
 	}
 
-	return []byte{}, err
+	return nil
 }
 
-func (c *Client) fetchHTTPResponse(req *http.Request) (response []byte, err error) {
+// fetches HTTP response of the request built with `newRequest`,
+// throttled with the client's rate limiter and retried with the client's retry policy (if any)
+func (c *Client) fetchHTTPResponse(ctx context.Context, api APIName, newRequest func() (*http.Request, error)) (response *Response, err error) {
+	requestID := newRequestID()
+
+	var lastErr error
+	for attempt := 1; ; attempt++ {
+		var req *http.Request
+		if req, err = newRequest(); err != nil {
+			if attempt > 1 {
+				// (eg. streamed files which cannot be rewound for retries)
+				if c.Verbose {
+					c.logger.Error("could not rebuild request for retry", "request_id", requestID, "error", err)
+				}
+				return response, lastErr
+			}
+			return nil, err
+		}
+
+		// (every attempt counts against the limits)
+		var release func()
+		if release, err = c.limiter.acquire(ctx, api); err != nil {
+			return nil, &ContextError{Method: req.Method, Path: req.URL.Path, Err: err}
+		}
+		response, err = c.doRequest(req, requestID, attempt)
+		release()
+
+		if err == nil || !c.Retry.shouldRetry(attempt, err) {
+			return response, err
+		}
+		lastErr = err
+
+		wait := c.Retry.backoff(attempt, err)
+
+		// verbose message for debugging
+		if c.Verbose {
+			c.logger.Warn("retrying request",
+				"request_id", requestID,
+				"method", req.Method,
+				"path", req.URL.Path,
+				"attempt", attempt+1,
+				"max_attempts", c.Retry.MaxAttempts,
+				"wait", wait,
+				"error", c.redact(err.Error()),
+			)
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return response, &ContextError{Method: req.Method, Path: req.URL.Path, Err: ctx.Err()}
+		case <-timer.C:
+		}
+	}
+}
+
+// sends given request and returns its response
+func (c *Client) doRequest(req *http.Request, requestID string, attempt int) (response *Response, err error) {
+	if len(c.userAgent) > 0 {
+		req.Header.Set("User-Agent", c.userAgent)
+	}
+
 	// verbose message for debugging
 	if c.Verbose {
-		if dumped, err := httputil.DumpRequest(req, true); err == nil {
-			log.Printf(`>>>>>> Request dump of %s %s:
-%s
-----------------`,
-				req.Method,
-				req.URL.Path,
-				string(dumped),
+		// (multipart bodies are not dumped, for they may contain binary files)
+		dumpBody := !strings.HasPrefix(req.Header.Get("Content-Type"), "multipart/")
+		if dumped, err := httputil.DumpRequest(req, dumpBody); err == nil {
+			c.logger.Debug("request dump",
+				"request_id", requestID,
+				"method", req.Method,
+				"path", req.URL.Path,
+				"dump", c.redact(string(dumped)),
 			)
 		}
 	}
 
+	started := time.Now()
+
 	var resp *http.Response
 	resp, err = c.httpClient.Do(req)
 
@@ -173,56 +404,105 @@ func (c *Client) fetchHTTPResponse(req *http.Request) (response []byte, err erro
 		// verbose message for debugging
 		if c.Verbose {
 			if dumped, err := httputil.DumpResponse(resp, true); err == nil {
-				log.Printf(`>>>>>> Response dump of %s %s:
-%s
-----------------`,
-					req.Method,
-					req.URL.Path,
-					string(dumped),
+				c.logger.Debug("response dump",
+					"request_id", requestID,
+					"method", req.Method,
+					"path", req.URL.Path,
+					"dump", c.redact(string(dumped)),
 				)
 			}
 		}
 
 		var bytes []byte
 		if bytes, err = ioutil.ReadAll(resp.Body); err == nil {
-			if resp.StatusCode == 200 {
-				return bytes, nil
+			// verbose message for debugging
+			if c.Verbose {
+				c.logger.Info("request finished",
+					"request_id", requestID,
+					"method", req.Method,
+					"path", req.URL.Path,
+					"attempt", attempt,
+					"status", resp.StatusCode,
+					"latency", time.Since(started),
+				)
 			}
 
-			var errResponse ResponseError
-			if err := json.Unmarshal(bytes, &errResponse); err == nil {
-				var err error
-
-				err = fmt.Errorf("API error with response code: %d, message: %s", errResponse.Code, errResponse.Msg)
+			response = &Response{
+				StatusCode: resp.StatusCode,
+				Header:     resp.Header,
+				Body:       bytes,
+			}
 
-				return bytes, err
+			if resp.StatusCode == http.StatusOK {
+				return response, nil
 			}
 
-			return bytes, fmt.Errorf("HTTP status %d %s", resp.StatusCode, string(bytes))
-		} else if c.Verbose {
-			// verbose message for debugging
-			log.Printf(`****** Error on %s %s request:
-%s
-----------------`,
-				req.Method,
-				req.URL.Path,
-				string(response),
-			)
+			return response, newAPIError(req.URL.Path, resp.StatusCode, resp.Header, bytes)
 		}
+	}
+
+	err = wrapContextError(req, err)
 
+	// verbose message for debugging
+	if c.Verbose {
+		c.logger.Error("request failed",
+			"request_id", requestID,
+			"method", req.Method,
+			"path", req.URL.Path,
+			"attempt", attempt,
+			"latency", time.Since(started),
+			"error", c.redact(err.Error()),
+		)
+	}
+
+	return nil, err
+}
+
+// wraps given error with the request's context error (if any),
+// so that cancellations and deadlines can be distinguished from other network errors
+func wrapContextError(req *http.Request, err error) error {
+	if ctxErr := req.Context().Err(); ctxErr != nil && err != nil {
+		return &ContextError{
+			Method: req.Method,
+			Path:   req.URL.Path,
+			Err:    ctxErr,
+		}
 	}
 
-	return []byte{}, err
+	return err
 }
 
-func (c *Client) authHeader(method authType) string {
-	return fmt.Sprintf("%s %s", method, c.apiKey)
+// returns the value of `Authorization` header for given auth type (empty if none)
+//
+// Bearer tokens are taken from the client's token source (refreshed if needed).
+func (c *Client) authHeader(ctx context.Context, method authType) (string, error) {
+	switch method {
+	case authTypeNone:
+		return "", nil
+	case authTypeAdminKey:
+		if len(c.adminKey) <= 0 {
+			return "", ErrNoAdminKey
+		}
+		return fmt.Sprintf("%s %s", authTypeKakaoAK, c.adminKey), nil
+	case authTypeBearer:
+		if c.tokenSource == nil {
+			return "", ErrNoTokenSource
+		}
+
+		token, err := c.tokenSource.Token(ctx)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%s %s", method, token.AccessToken), nil
+	default:
+		return fmt.Sprintf("%s %s", method, c.apiKey), nil
+	}
 }
 
 // checks if given `params` has any fileParam in it
 func hasFileInParams(params map[string]any) bool {
 	for _, v := range params {
-		if _, ok := v.(fileParam); ok {
+		if _, ok := v.(*fileParam); ok {
 			return true
 		}
 	}
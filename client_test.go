@@ -0,0 +1,218 @@
+package kakaoapi
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestContextDeadline(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(1 * time.Second):
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	_, err := client.post(ctx, "test", server.URL+"/generation", authTypeKakaoAK, nil, map[string]any{"prompt": "test"})
+	if err == nil {
+		t.Fatalf("should fail with deadline exceeded")
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("error should be context.DeadlineExceeded: %s", err)
+	}
+	var ctxErr *ContextError
+	if !errors.As(err, &ctxErr) {
+		t.Errorf("error should be a *ContextError: %T", err)
+	}
+}
+
+func TestContextCancel(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := client.get(ctx, "test", server.URL, authTypeKakaoAK, nil, nil)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("error should be context.Canceled: %s", err)
+	}
+}
+
+func TestAPIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/quota":
+			w.WriteHeader(http.StatusTooManyRequests)
+			w.Write([]byte(`{"code":-10,"msg":"API limit has been exceeded."}`))
+		case "/unauthorized":
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"code":-401,"msg":"wrong appKey format"}`))
+		case "/nsfw":
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"code":-9798,"msg":"inappropriate input"}`))
+		default:
+			w.WriteHeader(http.StatusBadGateway)
+			w.Write([]byte(`bad gateway`))
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key")
+
+	_, err := client.post(context.Background(), "test", server.URL+"/quota", authTypeKakaoAK, nil, map[string]any{})
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("error should be an *APIError: %T", err)
+	}
+	if apiErr.StatusCode != http.StatusTooManyRequests || apiErr.Code != ErrorCodeQuotaExceeded || apiErr.Path != "/quota" {
+		t.Errorf("unexpected error values: %+v", apiErr)
+	}
+	if !IsRateLimited(err) || !IsQuotaExceeded(err) {
+		t.Errorf("error should be rate-limited and quota-exceeded: %s", err)
+	}
+
+	_, err = client.post(context.Background(), "test", server.URL+"/unauthorized", authTypeKakaoAK, nil, map[string]any{})
+	if !IsInvalidAPIKey(err) {
+		t.Errorf("error should be an invalid API key error: %s", err)
+	}
+
+	_, err = client.post(context.Background(), "test", server.URL+"/nsfw", authTypeKakaoAK, nil, map[string]any{})
+	if !IsNSFWRejected(err) || IsRateLimited(err) {
+		t.Errorf("error should be an NSFW rejection only: %s", err)
+	}
+
+	_, err = client.get(context.Background(), "test", server.URL+"/unknown", authTypeKakaoAK, nil, nil)
+	if apiErr := AsAPIError(err); apiErr == nil || apiErr.StatusCode != http.StatusBadGateway || string(apiErr.Body) != "bad gateway" {
+		t.Errorf("unexpected error: %s", err)
+	}
+}
+
+func TestClientOptions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/kogpt/generation" {
+			t.Errorf("unexpected request path: %s", r.URL.Path)
+		}
+		if ua := r.Header.Get("User-Agent"); ua != "kakao-api-go/test" {
+			t.Errorf("unexpected user agent: %s", ua)
+		}
+		if auth := r.Header.Get("Authorization"); auth != "KakaoAK test-api-key" {
+			t.Errorf("unexpected auth header: %s", auth)
+		}
+
+		if strings.Contains(r.URL.RawQuery, "slow") {
+			time.Sleep(500 * time.Millisecond)
+		}
+
+		w.Write([]byte(`{"id":"test","generations":[{"text":"generated","tokens":1}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key",
+		WithBaseURL(ServiceKoGPT, server.URL+"/kogpt/"),
+		WithUserAgent("kakao-api-go/test"),
+		WithTimeout(100*time.Millisecond),
+	)
+
+	if generated, err := client.GenerateTexts(NewParamsTextGeneration("test", 10)); err != nil {
+		t.Errorf("failed to generate texts: %s", err)
+	} else if generated.ID != "test" || len(generated.Generations) != 1 {
+		t.Errorf("unexpected response: %+v", generated)
+	}
+
+	if _, err := client.get(context.Background(), "test", server.URL+"/kogpt/generation?slow", authTypeKakaoAK, nil, nil); err == nil {
+		t.Errorf("should fail with timeout")
+	}
+
+	// given http client should not be modified
+	httpClient := &http.Client{}
+	NewClient("test-api-key", WithHTTPClient(httpClient), WithTimeout(time.Second))
+	if httpClient.Timeout != 0 {
+		t.Errorf("given http client was modified")
+	}
+}
+
+func TestMultipartStreaming(t *testing.T) {
+	original, err := os.ReadFile(sampleImagePath)
+	if err != nil {
+		t.Fatalf("failed to read sample image file: %s", err)
+	}
+
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+
+		file, header, err := r.FormFile("image")
+		if err != nil {
+			t.Errorf("failed to get file from multipart: %s", err)
+			return
+		}
+		defer file.Close()
+
+		if contentType := header.Header.Get("Content-Type"); contentType != "image/jpeg" {
+			t.Errorf("unexpected content type of file: %s", contentType)
+		}
+		if header.Filename != "image.jpeg" {
+			t.Errorf("unexpected filename: %s", header.Filename)
+		}
+		if received, _ := io.ReadAll(file); !bytes.Equal(received, original) {
+			t.Errorf("received file is different from the original")
+		}
+		if value := r.FormValue("threshold"); value != "0.5" {
+			t.Errorf("unexpected value of field: %s", value)
+		}
+
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", WithRetryPolicy(testRetryPolicy(2)))
+
+	// seekable file: retried
+	file, err := newFileParamFromFilepath(sampleImagePath)
+	if err != nil {
+		t.Fatalf("failed to open sample image file: %s", err)
+	}
+	defer file.Close()
+	if _, err := client.post(context.Background(), "test", server.URL, authTypeKakaoAK, nil, map[string]any{
+		"image":     file,
+		"threshold": 0.5,
+	}); AsAPIError(err) == nil {
+		t.Errorf("should fail with API error: %v", err)
+	}
+	if attempts != 2 {
+		t.Errorf("should have attempted 2 times, but attempted %d times", attempts)
+	}
+
+	// non-seekable reader: not retried
+	atomic.StoreInt32(&attempts, 0)
+	if _, err := client.post(context.Background(), "test", server.URL, authTypeKakaoAK, nil, map[string]any{
+		"image":     newFileParamFromReader(io.MultiReader(bytes.NewReader(original))),
+		"threshold": 0.5,
+	}); AsAPIError(err) == nil {
+		t.Errorf("should fail with API error: %v", err)
+	}
+	if attempts != 1 {
+		t.Errorf("should have attempted only once, but attempted %d times", attempts)
+	}
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+
+	kakaoapi "github.com/meinside/kakao-api-go"
+	"github.com/meinside/kakao-api-go/batch"
+)
+
+// batch
+func runBatch(args []string, stdin io.Reader, stdout io.Writer) error {
+	fs := flag.NewFlagSet("batch", flag.ContinueOnError)
+	var common commonFlags
+	common.register(fs)
+	concurrency := fs.Int("concurrency", 4, "number of concurrent jobs")
+	rate := fs.Float64("rate", 0, "maximum requests per second for each API (no limit if 0)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if fs.NArg() != 1 {
+		return fmt.Errorf("path of an input JSONL file is needed")
+	}
+	input := fs.Arg(0)
+	prefix := strings.TrimSuffix(input, ".jsonl")
+
+	limiter := kakaoapi.NewRateLimiter(*concurrency)
+	if *rate > 0 {
+		limiter.SetLimit(kakaoapi.APIKoGPTGeneration, *rate, 1).
+			SetLimit(kakaoapi.APIKarloT2I, *rate, 1)
+	}
+	client, err := common.client(kakaoapi.WithRateLimiter(limiter))
+	if err != nil {
+		return err
+	}
+
+	// (interrupted runs can be resumed by running again)
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	summary, err := batch.Run(ctx, client, batch.Config{
+		InputPath:      input,
+		ResultsPath:    prefix + ".results.jsonl",
+		FailuresPath:   prefix + ".failures.jsonl",
+		CheckpointPath: prefix + ".checkpoint",
+		Concurrency:    *concurrency,
+	})
+
+	if printErr := common.print(stdout, summary, func(w io.Writer) {
+		fmt.Fprintln(w, summary)
+	}); err == nil {
+		err = printErr
+	}
+
+	return err
+}
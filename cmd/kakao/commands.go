@@ -0,0 +1,528 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	kakaoapi "github.com/meinside/kakao-api-go"
+)
+
+// flags common to all subcommands
+type commonFlags struct {
+	configPath   string
+	outputJSON   bool
+	verbose      bool
+	timeout      time.Duration
+	baseURLKoGPT string
+	baseURLKarlo string
+}
+
+// registers common flags to given flag set
+func (f *commonFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&f.configPath, "config", defaultConfigPath(), "path of the config file")
+	fs.BoolVar(&f.outputJSON, "json", false, "print results in JSON")
+	fs.BoolVar(&f.verbose, "verbose", false, "log verbose messages")
+	fs.DurationVar(&f.timeout, "timeout", 2*time.Minute, "timeout of each request")
+	fs.StringVar(&f.baseURLKoGPT, "base-url-kogpt", kakaoapi.APIBaseURLKoGPT, "base URL of KoGPT APIs")
+	fs.StringVar(&f.baseURLKarlo, "base-url-karlo", kakaoapi.APIBaseURLKarlo, "base URL of Karlo APIs")
+}
+
+// returns a new API client with the common flags and given options
+func (f *commonFlags) client(opts ...kakaoapi.ClientOption) (*kakaoapi.Client, error) {
+	apiKey, err := readAPIKey(f.configPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return kakaoapi.NewClient(apiKey, append([]kakaoapi.ClientOption{
+		kakaoapi.WithTimeout(f.timeout),
+		kakaoapi.WithBaseURL(kakaoapi.ServiceKoGPT, f.baseURLKoGPT),
+		kakaoapi.WithBaseURL(kakaoapi.ServiceKarlo, f.baseURLKarlo),
+		kakaoapi.WithRetryPolicy(kakaoapi.NewRetryPolicy(3)),
+		kakaoapi.WithVerbose(f.verbose),
+	}, opts...)...), nil
+}
+
+// prints given result as JSON, or with given function for human-readable output
+func (f *commonFlags) print(stdout io.Writer, result any, human func(io.Writer)) error {
+	if f.outputJSON {
+		encoder := json.NewEncoder(stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(result)
+	}
+
+	human(stdout)
+	return nil
+}
+
+// returns the names of flags which were set explicitly
+func setFlags(fs *flag.FlagSet) map[string]bool {
+	set := map[string]bool{}
+	fs.Visit(func(f *flag.Flag) {
+		set[f.Name] = true
+	})
+
+	return set
+}
+
+// parses comma-separated integers (eg. "1,2,3")
+func parseInts(str string) ([]int, error) {
+	ints := []int{}
+	for _, s := range strings.Split(str, ",") {
+		if s = strings.TrimSpace(s); len(s) > 0 {
+			i, err := strconv.Atoi(s)
+			if err != nil {
+				return nil, fmt.Errorf("invalid integer: '%s'", s)
+			}
+			ints = append(ints, i)
+		}
+	}
+
+	return ints, nil
+}
+
+// saved image file
+type savedImage struct {
+	ID   string `json:"id,omitempty"`
+	Seed *int64 `json:"seed,omitempty"`
+	Path string `json:"path"`
+
+	NSFWContentDetected bool     `json:"nsfw_content_detected,omitempty"`
+	NSFWScore           *float64 `json:"nsfw_score,omitempty"`
+}
+
+// saves given image (URL or base64-encoded string) to the directory with given name (extension is appended)
+func saveImage(ctx context.Context, client *kakaoapi.Client, dir, name, image string) (path string, err error) {
+	var bytes []byte
+	if bytes, err = client.ImageBytes(ctx, image); err == nil {
+		// (eg. "image/png" => "png")
+		extension := strings.TrimPrefix(strings.Split(http.DetectContentType(bytes), ";")[0], "image/")
+
+		if err = os.MkdirAll(dir, 0755); err == nil {
+			path = filepath.Join(dir, fmt.Sprintf("%s.%s", name, extension))
+			err = os.WriteFile(path, bytes, 0644)
+		}
+	}
+
+	return path, err
+}
+
+// saves given generated images to the directory as `<id>-<seed>.<ext>`
+func saveGeneratedImages(ctx context.Context, client *kakaoapi.Client, dir string, images []kakaoapi.GeneratedImage) ([]savedImage, error) {
+	saved := []savedImage{}
+	for _, image := range images {
+		seed := image.Seed
+
+		path, err := saveImage(ctx, client, dir, fmt.Sprintf("%s-%d", image.ID, seed), image.Image)
+		if err != nil {
+			return saved, fmt.Errorf("failed to save image %s: %s", image.ID, err)
+		}
+
+		saved = append(saved, savedImage{
+			ID:                  image.ID,
+			Seed:                &seed,
+			Path:                path,
+			NSFWContentDetected: image.NSFWContentDetected,
+			NSFWScore:           image.NSFWScore,
+		})
+	}
+
+	return saved, nil
+}
+
+// prints saved images in human-readable form
+func printSavedImages(w io.Writer, saved []savedImage) {
+	for _, image := range saved {
+		line := image.Path
+		if image.Seed != nil {
+			line += fmt.Sprintf(" (seed: %d)", *image.Seed)
+		}
+		if image.NSFWContentDetected {
+			line += " [NSFW]"
+		}
+		fmt.Fprintln(w, line)
+	}
+}
+
+// generate-text
+func runGenerateText(args []string, stdin io.Reader, stdout io.Writer) error {
+	fs := flag.NewFlagSet("generate-text", flag.ContinueOnError)
+	var common commonFlags
+	common.register(fs)
+	prompt := fs.String("prompt", "", "prompt (read from stdin if empty or '-')")
+	maxTokens := fs.Int("max-tokens", 64, "maximum number of tokens to generate")
+	temperature := fs.Float64("temperature", 1.0, "temperature (0.0 ~ 1.0)")
+	topP := fs.Float64("top-p", 1.0, "top_p (0.0 ~ 1.0)")
+	n := fs.Int("n", 1, "number of generations")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	set := setFlags(fs)
+
+	text, err := readPrompt(*prompt, stdin)
+	if err != nil {
+		return err
+	}
+
+	params := kakaoapi.NewParamsTextGeneration(text, *maxTokens)
+	if set["temperature"] {
+		params = params.SetTemp(*temperature)
+	}
+	if set["top-p"] {
+		params = params.SetTopP(*topP)
+	}
+	if set["n"] {
+		params = params.SetN(*n)
+	}
+
+	client, err := common.client()
+	if err != nil {
+		return err
+	}
+	generated, err := client.GenerateTexts(params)
+	if err != nil {
+		return err
+	}
+
+	return common.print(stdout, generated, func(w io.Writer) {
+		for i, generation := range generated.Generations {
+			if len(generated.Generations) > 1 {
+				fmt.Fprintf(w, "[%d] ", i+1)
+			}
+			fmt.Fprintf(w, "%s%s\n", text, generation.Text)
+		}
+	})
+}
+
+// flags common to image generating subcommands
+type imageFlags struct {
+	negativePrompt string
+	width          int
+	height         int
+	samples        int
+	format         string
+	quality        int
+	seed           string
+	scheduler      string
+	steps          int
+	guidanceScale  float64
+	upscale        bool
+	scale          int
+	nsfwChecker    bool
+	returnType     string
+	outDir         string
+}
+
+// registers image flags to given flag set
+func (f *imageFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&f.negativePrompt, "negative-prompt", "", "negative prompt")
+	fs.IntVar(&f.width, "width", 0, "width of images (multiple of 8)")
+	fs.IntVar(&f.height, "height", 0, "height of images (multiple of 8)")
+	fs.IntVar(&f.samples, "samples", 1, "number of images (1 ~ 8)")
+	fs.StringVar(&f.format, "format", string(kakaoapi.ImageFormatWEBP), "image format (webp, jpeg, png)")
+	fs.IntVar(&f.quality, "quality", 70, "image quality (1 ~ 100)")
+	fs.StringVar(&f.seed, "seed", "", "comma-separated seeds, one for each sample")
+	fs.StringVar(&f.scheduler, "scheduler", string(kakaoapi.ImageDecodeSchedulerDDIM), "decoder scheduler")
+	fs.IntVar(&f.steps, "steps", 50, "number of inference steps (10 ~ 100)")
+	fs.Float64Var(&f.guidanceScale, "guidance-scale", 5.0, "guidance scale (1.0 ~ 20.0)")
+	fs.BoolVar(&f.upscale, "upscale", false, "upscale generated images")
+	fs.IntVar(&f.scale, "scale", 2, "upscale scale (2 or 4)")
+	fs.BoolVar(&f.nsfwChecker, "nsfw-checker", false, "check NSFW of generated images")
+	fs.StringVar(&f.returnType, "return-type", string(kakaoapi.ImageReturnBase64), "return type of images (base64_string, url)")
+	fs.StringVar(&f.outDir, "out", ".", "directory for saving images")
+}
+
+// params of image generating subcommands
+type imageParams[P any] interface {
+	SetNegativePrompt(negativePrompt string) P
+	SetWidth(width int) P
+	SetHeight(height int) P
+	SetSamples(samples int) P
+	SetImageFormat(format kakaoapi.ImageFormat) P
+	SetImageQuality(quality int) P
+	SetSeed(seed []int) P
+	SetScheduler(scheduler kakaoapi.ImageDecodeScheduler) P
+	SetNumInferenceSteps(steps int) P
+	SetGuidanceScale(scale float64) P
+	SetUpscale(upscale bool) P
+	SetScale(scale int) P
+	SetNSFWChecker(nsfwChecker bool) P
+	SetReturnType(returnType kakaoapi.ImageReturnType) P
+}
+
+// applies given image flags (only the explicitly set ones, except the return type) to the params
+func applyImageFlags[P imageParams[P]](params P, images imageFlags, set map[string]bool) (P, error) {
+	params = params.SetReturnType(kakaoapi.ImageReturnType(images.returnType))
+	if set["negative-prompt"] {
+		params = params.SetNegativePrompt(images.negativePrompt)
+	}
+	if set["width"] {
+		params = params.SetWidth(images.width)
+	}
+	if set["height"] {
+		params = params.SetHeight(images.height)
+	}
+	if set["samples"] {
+		params = params.SetSamples(images.samples)
+	}
+	if set["format"] {
+		params = params.SetImageFormat(kakaoapi.ImageFormat(images.format))
+	}
+	if set["quality"] {
+		params = params.SetImageQuality(images.quality)
+	}
+	if set["seed"] {
+		seed, err := parseInts(images.seed)
+		if err != nil {
+			return params, err
+		}
+		params = params.SetSeed(seed)
+	}
+	if set["scheduler"] {
+		params = params.SetScheduler(kakaoapi.ImageDecodeScheduler(images.scheduler))
+	}
+	if set["steps"] {
+		params = params.SetNumInferenceSteps(images.steps)
+	}
+	if set["guidance-scale"] {
+		params = params.SetGuidanceScale(images.guidanceScale)
+	}
+	if set["upscale"] {
+		params = params.SetUpscale(images.upscale)
+	}
+	if set["scale"] {
+		params = params.SetScale(images.scale)
+	}
+	if set["nsfw-checker"] {
+		params = params.SetNSFWChecker(images.nsfwChecker)
+	}
+
+	return params, nil
+}
+
+// t2i
+func runT2I(args []string, stdin io.Reader, stdout io.Writer) error {
+	fs := flag.NewFlagSet("t2i", flag.ContinueOnError)
+	var common commonFlags
+	common.register(fs)
+	var images imageFlags
+	images.register(fs)
+	prompt := fs.String("prompt", "", "prompt (read from stdin if empty or '-')")
+	priorSteps := fs.Int("prior-steps", 25, "number of prior inference steps (10 ~ 100)")
+	priorGuidanceScale := fs.Float64("prior-guidance-scale", 5.0, "prior guidance scale (1.0 ~ 20.0)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	set := setFlags(fs)
+
+	text, err := readPrompt(*prompt, stdin)
+	if err != nil {
+		return err
+	}
+
+	params, err := applyImageFlags(kakaoapi.NewParamsImageGeneration(text), images, set)
+	if err != nil {
+		return err
+	}
+	if set["prior-steps"] {
+		params = params.SetPriorNumInferenceSteps(*priorSteps)
+	}
+	if set["prior-guidance-scale"] {
+		params = params.SetPriorGuidanceScale(*priorGuidanceScale)
+	}
+
+	client, err := common.client()
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+	generated, err := client.GenerateImagesWithContext(ctx, params)
+	if err != nil {
+		return err
+	}
+
+	saved, err := saveGeneratedImages(ctx, client, images.outDir, generated.Images)
+	if err != nil {
+		return err
+	}
+
+	return common.print(stdout, saved, func(w io.Writer) {
+		printSavedImages(w, saved)
+	})
+}
+
+// vary
+func runVary(args []string, stdin io.Reader, stdout io.Writer) error {
+	fs := flag.NewFlagSet("vary", flag.ContinueOnError)
+	var common commonFlags
+	common.register(fs)
+	var images imageFlags
+	images.register(fs)
+	prompt := fs.String("prompt", "", "prompt for the variation")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	set := setFlags(fs)
+
+	if fs.NArg() != 1 {
+		return fmt.Errorf("path of an image file is needed")
+	}
+	image, _, err := kakaoapi.EncodeImageFile(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	params, err := applyImageFlags(kakaoapi.NewParamsImageVariation(image, *prompt), images, set)
+	if err != nil {
+		return err
+	}
+
+	client, err := common.client()
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+	varied, err := client.VaryImageWithContext(ctx, params)
+	if err != nil {
+		return err
+	}
+
+	saved, err := saveGeneratedImages(ctx, client, images.outDir, varied.Images)
+	if err != nil {
+		return err
+	}
+
+	return common.print(stdout, saved, func(w io.Writer) {
+		printSavedImages(w, saved)
+	})
+}
+
+// upscale
+func runUpscale(args []string, stdin io.Reader, stdout io.Writer) error {
+	fs := flag.NewFlagSet("upscale", flag.ContinueOnError)
+	var common commonFlags
+	common.register(fs)
+	scale := fs.Int("scale", 2, "upscale scale (2 or 4)")
+	format := fs.String("format", string(kakaoapi.ImageFormatWEBP), "image format (webp, jpeg, png)")
+	quality := fs.Int("quality", 70, "image quality (1 ~ 100)")
+	returnType := fs.String("return-type", string(kakaoapi.ImageReturnBase64), "return type of images (base64_string, url)")
+	outDir := fs.String("out", ".", "directory for saving images")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	set := setFlags(fs)
+
+	if fs.NArg() <= 0 {
+		return fmt.Errorf("paths of image files are needed")
+	}
+	images := []string{}
+	for _, path := range fs.Args() {
+		image, _, err := kakaoapi.EncodeImageFile(path)
+		if err != nil {
+			return err
+		}
+		images = append(images, image)
+	}
+
+	params := kakaoapi.NewParamsImageUpscale(images).
+		SetReturnType(kakaoapi.ImageReturnType(*returnType))
+	if set["scale"] {
+		params = params.SetScale(*scale)
+	}
+	if set["format"] {
+		params = params.SetImageFormat(kakaoapi.ImageFormat(*format))
+	}
+	if set["quality"] {
+		params = params.SetImageQuality(*quality)
+	}
+
+	client, err := common.client()
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+	upscaled, err := client.UpscaleImagesWithContext(ctx, params)
+	if err != nil {
+		return err
+	}
+
+	// saved as `<original filename>-upscaled.<ext>`
+	saved := []savedImage{}
+	for i, image := range upscaled.Images {
+		name := strings.TrimSuffix(filepath.Base(fs.Arg(i)), filepath.Ext(fs.Arg(i))) + "-upscaled"
+		path, err := saveImage(ctx, client, *outDir, name, image)
+		if err != nil {
+			return fmt.Errorf("failed to save upscaled image of %s: %s", fs.Arg(i), err)
+		}
+		saved = append(saved, savedImage{Path: path})
+	}
+
+	return common.print(stdout, saved, func(w io.Writer) {
+		printSavedImages(w, saved)
+	})
+}
+
+// nsfw
+func runNSFW(args []string, stdin io.Reader, stdout io.Writer) error {
+	fs := flag.NewFlagSet("nsfw", flag.ContinueOnError)
+	var common commonFlags
+	common.register(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if fs.NArg() <= 0 {
+		return fmt.Errorf("paths of image files are needed")
+	}
+	images := []string{}
+	for _, path := range fs.Args() {
+		image, _, err := kakaoapi.EncodeImageFile(path)
+		if err != nil {
+			return err
+		}
+		images = append(images, image)
+	}
+
+	client, err := common.client()
+	if err != nil {
+		return err
+	}
+	checked, err := client.CheckNSFW(images)
+	if err != nil {
+		return err
+	}
+
+	// (results are in the order of given images)
+	type result struct {
+		Path                string  `json:"path"`
+		NSFWContentDetected bool    `json:"nsfw_content_detected"`
+		NSFWScore           float64 `json:"nsfw_score"`
+	}
+	results := []result{}
+	for i, r := range checked.Results {
+		results = append(results, result{
+			Path:                fs.Arg(i),
+			NSFWContentDetected: r.NSFWContentDetected,
+			NSFWScore:           r.NSFWScore,
+		})
+	}
+
+	return common.print(stdout, results, func(w io.Writer) {
+		for _, r := range results {
+			verdict := "safe"
+			if r.NSFWContentDetected {
+				verdict = "NSFW"
+			}
+			fmt.Fprintf(w, "%s: %s (score: %.4f)\n", r.Path, verdict, r.NSFWScore)
+		}
+	})
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	envAPIKey         = "KAKAO_API_KEY"
+	defaultConfigDir  = "kakao-api-go"
+	defaultConfigFile = "config.json"
+)
+
+// config file struct
+type config struct {
+	APIKey string `json:"api_key"`
+}
+
+// returns the default path of the config file (eg. `~/.config/kakao-api-go/config.json`)
+func defaultConfigPath() string {
+	if dir, err := os.UserConfigDir(); err == nil {
+		return filepath.Join(dir, defaultConfigDir, defaultConfigFile)
+	}
+
+	return ""
+}
+
+// reads the API key from the environment variable, or from the config file at given path
+func readAPIKey(configPath string) (string, error) {
+	if apiKey := os.Getenv(envAPIKey); len(apiKey) > 0 {
+		return apiKey, nil
+	}
+
+	if len(configPath) <= 0 {
+		return "", fmt.Errorf("API key not found: set `%s` or create a config file", envAPIKey)
+	}
+
+	bytes, err := os.ReadFile(configPath)
+	if err != nil {
+		return "", fmt.Errorf("API key not found: set `%s` or create a config file (%s)", envAPIKey, err)
+	}
+
+	var conf config
+	if err := json.Unmarshal(bytes, &conf); err != nil {
+		return "", fmt.Errorf("failed to parse config file %s: %s", configPath, err)
+	}
+	if len(conf.APIKey) <= 0 {
+		return "", fmt.Errorf("`api_key` is missing in config file %s", configPath)
+	}
+
+	return conf.APIKey, nil
+}
+
+// returns given prompt, or reads it from stdin if it is empty or "-"
+func readPrompt(prompt string, stdin io.Reader) (string, error) {
+	if len(prompt) > 0 && prompt != "-" {
+		return prompt, nil
+	}
+
+	bytes, err := io.ReadAll(stdin)
+	if err != nil {
+		return "", fmt.Errorf("failed to read prompt from stdin: %s", err)
+	}
+
+	if prompt = strings.TrimSpace(string(bytes)); len(prompt) <= 0 {
+		return "", fmt.Errorf("prompt is empty")
+	}
+
+	return prompt, nil
+}
@@ -0,0 +1,77 @@
+// Command kakao is a command-line tool for KoGPT and Karlo APIs.
+//
+//	$ export KAKAO_API_KEY=0123456789abcdefghijklmnopqrstuvwxyz
+//	$ kakao generate-text -prompt "오늘 아침 하늘은" -max-tokens 64
+//	$ echo "A cat with white fur" | kakao t2i -samples 2 -out ./images
+//	$ kakao upscale -scale 2 ./images/*.webp
+//	$ kakao vary -prompt "modern" ./sample/image.jpg
+//	$ kakao nsfw ./sample/image.jpg
+//	$ kakao batch -concurrency 4 -rate 1 ./prompts.jsonl
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+)
+
+// subcommand function
+type command func(args []string, stdin io.Reader, stdout io.Writer) error
+
+var commands = map[string]struct {
+	run         command
+	description string
+}{
+	"generate-text": {runGenerateText, "generate texts with KoGPT"},
+	"t2i":           {runT2I, "generate images from a prompt with Karlo"},
+	"upscale":       {runUpscale, "upscale images with Karlo"},
+	"vary":          {runVary, "generate variations of an image with Karlo"},
+	"nsfw":          {runNSFW, "check whether images are NSFW with Karlo"},
+	"batch":         {runBatch, "run requests in a JSONL file, resumably"},
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// runs the subcommand in given args, and returns the exit code
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	if len(args) <= 0 {
+		printUsage(stderr)
+		return 2
+	}
+
+	cmd, exists := commands[args[0]]
+	if !exists {
+		if args[0] != "help" && args[0] != "-h" && args[0] != "-help" {
+			fmt.Fprintf(stderr, "unknown command: %s\n\n", args[0])
+		}
+		printUsage(stderr)
+		return 2
+	}
+
+	if err := cmd.run(args[1:], stdin, stdout); err != nil {
+		fmt.Fprintf(stderr, "%s: %s\n", args[0], err)
+		return 1
+	}
+
+	return 0
+}
+
+// prints the usage of this command
+func printUsage(w io.Writer) {
+	fmt.Fprintf(w, "Usage: kakao <command> [flags]\n\nCommands:\n")
+
+	names := []string{}
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(w, "  %-14s %s\n", name, commands[name].description)
+	}
+
+	fmt.Fprintf(w, "\nRun `kakao <command> -h` for the flags of each command.\n")
+	fmt.Fprintf(w, "API key is read from `%s`, or from the config file (default: %s).\n", envAPIKey, defaultConfigPath())
+}
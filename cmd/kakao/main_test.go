@@ -0,0 +1,96 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/meinside/kakao-api-go/kakaoapitest"
+)
+
+// runs the command against the fake server and returns its exit code and outputs
+func runWithServer(t *testing.T, server *kakaoapitest.Server, stdin string, args ...string) (int, string, string) {
+	t.Helper()
+	t.Setenv(envAPIKey, server.APIKey)
+
+	var stdout, stderr bytes.Buffer
+	args = append([]string{args[0],
+		"-base-url-kogpt", server.KoGPTURL(),
+		"-base-url-karlo", server.KarloURL(),
+	}, args[1:]...)
+	code := run(args, strings.NewReader(stdin), &stdout, &stderr)
+
+	return code, stdout.String(), stderr.String()
+}
+
+func TestGenerateText(t *testing.T) {
+	server := kakaoapitest.NewServer()
+	defer server.Close()
+
+	code, stdout, stderr := runWithServer(t, server, "오늘 아침 하늘은\n", "generate-text", "-n", "2", "-json")
+	if code != 0 {
+		t.Fatalf("failed with code %d: %s", code, stderr)
+	}
+
+	var result struct {
+		Generations []struct {
+			Text string `json:"text"`
+		} `json:"generations"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &result); err != nil || len(result.Generations) != 2 {
+		t.Errorf("unexpected output: %s", stdout)
+	}
+	server.AssertParam(t, kakaoapitest.EndpointKoGPTGeneration, "prompt", "오늘 아침 하늘은")
+	server.AssertParam(t, kakaoapitest.EndpointKoGPTGeneration, "n", 2)
+}
+
+func TestT2I(t *testing.T) {
+	server := kakaoapitest.NewServer()
+	defer server.Close()
+
+	outDir := t.TempDir()
+	code, stdout, stderr := runWithServer(t, server, "", "t2i", "-prompt", "A cat", "-samples", "2", "-seed", "7,8", "-out", outDir)
+	if code != 0 {
+		t.Fatalf("failed with code %d: %s", code, stderr)
+	}
+
+	files, _ := filepath.Glob(filepath.Join(outDir, "*.png"))
+	if len(files) != 2 {
+		t.Errorf("2 image files should have been saved: %v\n%s", files, stdout)
+	}
+	for _, file := range files {
+		if !strings.HasSuffix(file, "-7.png") && !strings.HasSuffix(file, "-8.png") {
+			t.Errorf("file should be named with its seed: %s", file)
+		}
+	}
+	server.AssertParam(t, kakaoapitest.EndpointKarloT2I, "seed", []any{7, 8})
+}
+
+func TestNSFW(t *testing.T) {
+	server := kakaoapitest.NewServer()
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "image.png")
+	os.WriteFile(path, kakaoapitest.SampleImage(), 0644)
+
+	code, stdout, stderr := runWithServer(t, server, "", "nsfw", path)
+	if code != 0 {
+		t.Fatalf("failed with code %d: %s", code, stderr)
+	}
+	if !strings.Contains(stdout, path+": safe") {
+		t.Errorf("unexpected output: %s", stdout)
+	}
+}
+
+func TestUnknownCommand(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"unknown"}, strings.NewReader(""), &stdout, &stderr); code != 2 {
+		t.Errorf("should fail with code 2, but was %d", code)
+	}
+	if !strings.Contains(stderr.String(), "Usage:") {
+		t.Errorf("usage should be printed: %s", stderr.String())
+	}
+}
@@ -0,0 +1,175 @@
+package kakaoapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Kakao API error codes
+//
+// https://developers.kakao.com/docs/latest/ko/reference/rest-api-reference
+const (
+	ErrorCodeInternal           = -1
+	ErrorCodeInvalidParameter   = -2
+	ErrorCodeDisabledFeature    = -3
+	ErrorCodeBlockedAccount     = -4
+	ErrorCodeNoPermission       = -5
+	ErrorCodeServiceMaintenance = -7
+	ErrorCodeInvalidHeader      = -8
+	ErrorCodeDeprecatedAPI      = -9
+	ErrorCodeQuotaExceeded      = -10
+	ErrorCodeInvalidAPIKey      = -401
+	ErrorCodeInappropriateInput = -9798 // KoGPT/Karlo: rejected by the content policy
+)
+
+// Kakao Login error names
+//
+// https://developers.kakao.com/docs/latest/ko/kakaologin/trouble-shooting
+const (
+	OAuthErrorInvalidGrant   = "invalid_grant"
+	OAuthErrorInvalidClient  = "invalid_client"
+	OAuthErrorInvalidRequest = "invalid_request"
+)
+
+// APIError is returned when the API responded with a non-successful status.
+//
+// It can be retrieved from the returned error with `errors.As`.
+type APIError struct {
+	StatusCode int    // HTTP status code
+	Code       int    // Kakao error code (0 if not given)
+	Message    string // Kakao error message
+	Path       string // path of the request
+	Body       []byte // raw response body
+
+	OAuthError     string // Kakao Login error name (eg. "invalid_grant")
+	OAuthErrorCode string // Kakao Login error code (eg. "KOE320")
+
+	RetryAfter time.Duration // parsed value of `Retry-After` header (0 if not given)
+}
+
+// Error returns the string representation of APIError.
+func (e *APIError) Error() string {
+	if e.Code != 0 || len(e.Message) > 0 {
+		return fmt.Sprintf("API error on %s with HTTP status: %d, code: %d, message: %s", e.Path, e.StatusCode, e.Code, e.Message)
+	}
+
+	return fmt.Sprintf("API error on %s with HTTP status: %d, body: %s", e.Path, e.StatusCode, string(e.Body))
+}
+
+// newAPIError creates a new APIError from given HTTP response values.
+func newAPIError(path string, statusCode int, header http.Header, body []byte) *APIError {
+	apiErr := &APIError{
+		StatusCode: statusCode,
+		Path:       path,
+		Body:       body,
+		RetryAfter: parseRetryAfter(header.Get("Retry-After")),
+	}
+
+	var errResponse ResponseError
+	if err := json.Unmarshal(body, &errResponse); err == nil {
+		apiErr.Code = errResponse.Code
+		apiErr.Message = errResponse.Msg
+		if len(apiErr.Message) <= 0 {
+			apiErr.Message = errResponse.Message
+		}
+		if len(apiErr.Message) <= 0 {
+			apiErr.Message = errResponse.ErrorDescription
+		}
+		apiErr.OAuthError = errResponse.Error
+		apiErr.OAuthErrorCode = errResponse.ErrorCode
+	}
+
+	return apiErr
+}
+
+// ContextError is returned when a request was aborted by its context
+// (eg. canceled or deadline exceeded).
+//
+// It wraps `context.Canceled` or `context.DeadlineExceeded`,
+// so it can be checked with `errors.Is`.
+type ContextError struct {
+	Method string
+	Path   string
+	Err    error
+}
+
+// Error returns the string representation of ContextError.
+func (e *ContextError) Error() string {
+	return fmt.Sprintf("request %s %s aborted: %s", e.Method, e.Path, e.Err)
+}
+
+// Unwrap returns the underlying context error.
+func (e *ContextError) Unwrap() error {
+	return e.Err
+}
+
+// AsAPIError returns the *APIError in given error's chain, or nil if there is none.
+func AsAPIError(err error) *APIError {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr
+	}
+
+	return nil
+}
+
+// IsRateLimited checks if given error is from a rate-limited (HTTP 429) request.
+func IsRateLimited(err error) bool {
+	if apiErr := AsAPIError(err); apiErr != nil {
+		return apiErr.StatusCode == http.StatusTooManyRequests
+	}
+
+	return false
+}
+
+// IsQuotaExceeded checks if given error is from a request which exceeded the app's quota.
+func IsQuotaExceeded(err error) bool {
+	if apiErr := AsAPIError(err); apiErr != nil {
+		return apiErr.Code == ErrorCodeQuotaExceeded
+	}
+
+	return false
+}
+
+// IsInvalidAPIKey checks if given error is from a request with an invalid API key.
+func IsInvalidAPIKey(err error) bool {
+	if apiErr := AsAPIError(err); apiErr != nil {
+		return apiErr.Code == ErrorCodeInvalidAPIKey || apiErr.StatusCode == http.StatusUnauthorized
+	}
+
+	return false
+}
+
+// IsNSFWRejected checks if given error is from a request rejected by the content policy.
+func IsNSFWRejected(err error) bool {
+	if apiErr := AsAPIError(err); apiErr != nil {
+		return apiErr.Code == ErrorCodeInappropriateInput
+	}
+
+	return false
+}
+
+// IsInvalidGrant checks if given error is from a Kakao Login request with an invalid (eg. expired) code or refresh token.
+func IsInvalidGrant(err error) bool {
+	if apiErr := AsAPIError(err); apiErr != nil {
+		return apiErr.OAuthError == OAuthErrorInvalidGrant
+	}
+
+	return false
+}
+
+// ValidationError is returned when request parameters are invalid.
+//
+// (multiple ValidationErrors are joined with `errors.Join`)
+type ValidationError struct {
+	Field   string // name of the invalid parameter
+	Message string // reason of the invalidity
+}
+
+// Error returns the string representation of ValidationError.
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("invalid parameter '%s': %s", e.Field, e.Message)
+}
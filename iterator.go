@@ -0,0 +1,85 @@
+package kakaoapi
+
+import (
+	"context"
+)
+
+// Iterator walks through the items of a paginated API, fetching pages as needed
+//
+//	it := client.FriendsIterator(kakaoapi.NewParamsFriends())
+//	for it.Next(ctx) {
+//		friend := it.Value()
+//		// ...
+//	}
+//	if err := it.Err(); err != nil {
+//		// ...
+//	}
+type Iterator[T any] struct {
+	fetch func(ctx context.Context) (items []T, hasNext bool, err error)
+
+	items   []T
+	index   int
+	hasNext bool
+	current T
+	err     error
+}
+
+// returns a new Iterator which fetches pages with given function
+//
+// `fetch` returns the items of the next page, and whether there are more pages after it.
+func newIterator[T any](fetch func(ctx context.Context) (items []T, hasNext bool, err error)) *Iterator[T] {
+	return &Iterator[T]{
+		fetch:   fetch,
+		hasNext: true,
+	}
+}
+
+// Next advances the iterator to the next item, fetching the next page if needed.
+//
+// It returns false when there are no more items, or an error occurred (see Err).
+func (it *Iterator[T]) Next(ctx context.Context) bool {
+	if it.err != nil {
+		return false
+	}
+
+	for it.index >= len(it.items) {
+		if !it.hasNext {
+			return false
+		}
+		if err := ctx.Err(); err != nil {
+			it.err = err
+			return false
+		}
+
+		it.items, it.hasNext, it.err = it.fetch(ctx)
+		it.index = 0
+		if it.err != nil {
+			return false
+		}
+	}
+
+	it.current = it.items[it.index]
+	it.index++
+
+	return true
+}
+
+// Value returns the current item.
+func (it *Iterator[T]) Value() T {
+	return it.current
+}
+
+// Err returns the error which stopped the iteration, if any.
+func (it *Iterator[T]) Err() error {
+	return it.err
+}
+
+// All iterates all remaining items, and returns them.
+func (it *Iterator[T]) All(ctx context.Context) ([]T, error) {
+	var all []T
+	for it.Next(ctx) {
+		all = append(all, it.Value())
+	}
+
+	return all, it.Err()
+}
@@ -0,0 +1,50 @@
+package kakaoapitest
+
+import (
+	"fmt"
+	"testing"
+)
+
+// AssertCalled fails the test if the endpoint was not called exactly `times` times.
+func (s *Server) AssertCalled(t testing.TB, endpoint Endpoint, times int) {
+	t.Helper()
+
+	if called := len(s.Requests(endpoint)); called != times {
+		t.Errorf("kakaoapitest: %s should have been called %d time(s), but was called %d time(s)", endpoint, times, called)
+	}
+}
+
+// AssertParam fails the test if the last request of the endpoint does not have given param value.
+//
+// Values are compared in their JSON-decoded forms (eg. numbers as float64).
+func (s *Server) AssertParam(t testing.TB, endpoint Endpoint, key string, expected any) {
+	t.Helper()
+
+	requests := s.Requests(endpoint)
+	if len(requests) <= 0 {
+		t.Errorf("kakaoapitest: %s was not called", endpoint)
+		return
+	}
+
+	last := requests[len(requests)-1]
+	if actual, exists := last.Params[key]; !exists {
+		t.Errorf("kakaoapitest: param '%s' of %s is missing", key, endpoint)
+	} else if fmt.Sprintf("%v", actual) != fmt.Sprintf("%v", expected) {
+		t.Errorf("kakaoapitest: param '%s' of %s should be %v, but was %v", key, endpoint, expected, actual)
+	}
+}
+
+// AssertHeader fails the test if the last request of the endpoint does not have given header value.
+func (s *Server) AssertHeader(t testing.TB, endpoint Endpoint, key, expected string) {
+	t.Helper()
+
+	requests := s.Requests(endpoint)
+	if len(requests) <= 0 {
+		t.Errorf("kakaoapitest: %s was not called", endpoint)
+		return
+	}
+
+	if actual := requests[len(requests)-1].Header.Get(key); actual != expected {
+		t.Errorf("kakaoapitest: header '%s' of %s should be '%s', but was '%s'", key, endpoint, expected, actual)
+	}
+}
@@ -0,0 +1,303 @@
+package kakaoapitest
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// CassetteMode is the mode of a Cassette
+type CassetteMode int
+
+// CassetteModes
+const (
+	CassetteModeReplay CassetteMode = iota // replay recorded interactions (no network)
+	CassetteModeRecord                     // record interactions with the real APIs
+)
+
+// EnvRecord is the name of the environment variable for selecting the record mode
+const EnvRecord = "KAKAO_RECORD"
+
+// CassetteModeFromEnv returns CassetteModeRecord if the environment variable `KAKAO_RECORD` is "1",
+// or CassetteModeReplay otherwise.
+func CassetteModeFromEnv() CassetteMode {
+	if os.Getenv(EnvRecord) == "1" {
+		return CassetteModeRecord
+	}
+
+	return CassetteModeReplay
+}
+
+// Interaction is a recorded pair of request and response
+type Interaction struct {
+	Request struct {
+		Method string `json:"method"`
+		Path   string `json:"path"`
+		Query  string `json:"query,omitempty"`
+		Body   string `json:"body,omitempty"` // scrubbed body
+	} `json:"request"`
+	Response struct {
+		Status      int    `json:"status"`
+		ContentType string `json:"content_type,omitempty"`
+		Body        string `json:"body,omitempty"` // scrubbed body
+	} `json:"response"`
+
+	replayed bool
+}
+
+// cassette file format
+type cassetteFile struct {
+	Note         string         `json:"note,omitempty"`
+	Interactions []*Interaction `json:"interactions"`
+}
+
+// Cassette is an `http.RoundTripper` which records interactions with Kakao APIs to a golden file,
+// or replays them from the file.
+//
+// API keys are never recorded, and base64-encoded images are hashed in requests
+// and replaced with a small sample image in responses.
+type Cassette struct {
+	path      string
+	mode      CassetteMode
+	transport http.RoundTripper
+
+	mu           sync.Mutex
+	note         string
+	interactions []*Interaction
+}
+
+// NewCassette returns a new Cassette for the golden file at `path`.
+//
+// In replay mode, the file is loaded and interactions are replayed from it.
+// In record mode, requests are sent with `transport` (`http.DefaultTransport` if nil),
+// and recorded interactions are written to the file with Save.
+func NewCassette(path string, mode CassetteMode, transport http.RoundTripper) (*Cassette, error) {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	c := &Cassette{
+		path:      path,
+		mode:      mode,
+		transport: transport,
+	}
+
+	if mode == CassetteModeReplay {
+		bytes, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("kakaoapitest: failed to load cassette: %w", err)
+		}
+
+		var file cassetteFile
+		if err := json.Unmarshal(bytes, &file); err != nil {
+			return nil, fmt.Errorf("kakaoapitest: failed to decode cassette %s: %w", path, err)
+		}
+		c.note, c.interactions = file.Note, file.Interactions
+	}
+
+	return c, nil
+}
+
+// Mode returns the mode of the cassette.
+func (c *Cassette) Mode() CassetteMode {
+	return c.mode
+}
+
+// SetNote sets a note to be saved with the recorded interactions.
+func (c *Cassette) SetNote(note string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.note = note
+}
+
+// RoundTrip records or replays given request.
+func (c *Cassette) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		if body, err = io.ReadAll(req.Body); err != nil {
+			return nil, err
+		}
+		req.Body.Close()
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	if c.mode == CassetteModeRecord {
+		return c.record(req, body)
+	}
+
+	return c.replay(req, body)
+}
+
+// sends given request and records the interaction
+func (c *Cassette) record(req *http.Request, body []byte) (*http.Response, error) {
+	resp, err := c.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var respBody []byte
+	if respBody, err = io.ReadAll(resp.Body); err != nil {
+		return nil, err
+	}
+
+	interaction := &Interaction{}
+	interaction.Request.Method = req.Method
+	interaction.Request.Path = req.URL.Path
+	interaction.Request.Query = req.URL.RawQuery
+	interaction.Request.Body = scrubRequestBody(req.Header.Get("Content-Type"), body)
+	interaction.Response.Status = resp.StatusCode
+	interaction.Response.ContentType = resp.Header.Get("Content-Type")
+	interaction.Response.Body = scrubResponseBody(respBody)
+
+	c.mu.Lock()
+	c.interactions = append(c.interactions, interaction)
+	c.mu.Unlock()
+
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	return resp, nil
+}
+
+// returns the response of a matching recorded interaction
+func (c *Cassette) replay(req *http.Request, body []byte) (*http.Response, error) {
+	scrubbed := scrubRequestBody(req.Header.Get("Content-Type"), body)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, interaction := range c.interactions {
+		if interaction.replayed ||
+			interaction.Request.Method != req.Method ||
+			interaction.Request.Path != req.URL.Path ||
+			interaction.Request.Query != req.URL.RawQuery ||
+			interaction.Request.Body != scrubbed {
+			continue
+		}
+		interaction.replayed = true
+
+		header := http.Header{}
+		if len(interaction.Response.ContentType) > 0 {
+			header.Set("Content-Type", interaction.Response.ContentType)
+		}
+
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", interaction.Response.Status, http.StatusText(interaction.Response.Status)),
+			StatusCode:    interaction.Response.Status,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        header,
+			Body:          io.NopCloser(bytes.NewReader([]byte(interaction.Response.Body))),
+			ContentLength: int64(len(interaction.Response.Body)),
+			Request:       req,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("kakaoapitest: no recorded interaction for %s %s in %s (record it with %s=1)", req.Method, req.URL.Path, c.path, EnvRecord)
+}
+
+// Save writes the recorded interactions to the golden file (only in record mode).
+func (c *Cassette) Save() error {
+	if c.mode != CassetteModeRecord {
+		return nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	bytes, err := json.MarshalIndent(cassetteFile{
+		Note:         c.note,
+		Interactions: c.interactions,
+	}, "", "  ")
+	if err == nil {
+		if err = os.MkdirAll(filepath.Dir(c.path), 0755); err == nil {
+			err = os.WriteFile(c.path, append(bytes, '\n'), 0644)
+		}
+	}
+
+	return err
+}
+
+const minBase64Length = 128 // strings longer than this are considered as base64-encoded images
+
+var base64Regex = regexp.MustCompile(fmt.Sprintf(`"[A-Za-z0-9+/]{%d,}={0,2}"`, minBase64Length))
+
+// replaces base64-encoded images in given request body with their hashes
+//
+// Multipart bodies are canonicalized (see canonicalMultipart), for their boundaries are random.
+func scrubRequestBody(contentType string, body []byte) string {
+	if mediaType, params, err := mime.ParseMediaType(contentType); err == nil && strings.HasPrefix(mediaType, "multipart/") {
+		if canonical, err := canonicalMultipart(body, params["boundary"]); err == nil {
+			return canonical
+		}
+	}
+
+	return base64Regex.ReplaceAllStringFunc(string(canonicalJSON(body)), func(quoted string) string {
+		hash := sha256.Sum256([]byte(quoted[1 : len(quoted)-1]))
+		return fmt.Sprintf(`"sha256:%s"`, hex.EncodeToString(hash[:]))
+	})
+}
+
+// replaces base64-encoded images in given response body with a small sample image
+func scrubResponseBody(body []byte) string {
+	sample := fmt.Sprintf(`"%s"`, base64Encode(SampleImage()))
+
+	return base64Regex.ReplaceAllString(string(canonicalJSON(body)), sample)
+}
+
+// re-encodes given multipart body in a canonical form, independent of its boundary and the order of parts:
+// sorted lines of `name=value` for fields, and `name=sha256:<hash>` for files
+func canonicalMultipart(body []byte, boundary string) (string, error) {
+	var lines []string
+
+	reader := multipart.NewReader(bytes.NewReader(body), boundary)
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return "", err
+		}
+
+		var value []byte
+		if value, err = io.ReadAll(part); err != nil {
+			return "", err
+		}
+		if len(part.FileName()) > 0 {
+			hash := sha256.Sum256(value)
+			lines = append(lines, fmt.Sprintf("%s=sha256:%s", part.FormName(), hex.EncodeToString(hash[:])))
+		} else {
+			lines = append(lines, fmt.Sprintf("%s=%s", part.FormName(), value))
+		}
+	}
+	sort.Strings(lines)
+
+	return strings.Join(lines, "\n"), nil
+}
+
+// re-encodes given JSON bytes in a canonical form (sorted keys, no spaces), or returns them as they are
+func canonicalJSON(body []byte) []byte {
+	var v any
+	if err := json.Unmarshal(body, &v); err == nil {
+		if canonical, err := json.Marshal(v); err == nil {
+			return canonical
+		}
+	}
+
+	return body
+}
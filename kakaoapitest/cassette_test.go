@@ -0,0 +1,121 @@
+package kakaoapitest
+
+import (
+	"bytes"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCassette(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	image := base64Encode([]byte(strings.Repeat("image bytes ", 100)))
+	body := `{"images":["` + image + `"],"return_type":"base64_string"}`
+
+	send := func(c *Cassette) (int, string) {
+		req, _ := http.NewRequest("POST", s.URL+pathKarlo+"/upscale", strings.NewReader(body))
+		req.Header.Set("Authorization", "KakaoAK "+s.APIKey)
+
+		resp, err := (&http.Client{Transport: c}).Do(req)
+		if err != nil {
+			t.Fatalf("failed to send request: %s", err)
+		}
+		defer resp.Body.Close()
+
+		b, _ := io.ReadAll(resp.Body)
+		return resp.StatusCode, string(b)
+	}
+
+	// record
+	recorder, err := NewCassette(path, CassetteModeRecord, nil)
+	if err != nil {
+		t.Fatalf("failed to create cassette: %s", err)
+	}
+	if status, _ := send(recorder); status != http.StatusOK {
+		t.Errorf("unexpected status while recording: %d", status)
+	}
+	if err := recorder.Save(); err != nil {
+		t.Fatalf("failed to save cassette: %s", err)
+	}
+
+	saved, _ := os.ReadFile(path)
+	if strings.Contains(string(saved), s.APIKey) {
+		t.Errorf("API key was recorded: %s", saved)
+	}
+	if strings.Contains(string(saved), image) {
+		t.Errorf("base64 image was not scrubbed: %s", saved)
+	}
+
+	// replay (without the server)
+	s.Close()
+	player, err := NewCassette(path, CassetteModeReplay, nil)
+	if err != nil {
+		t.Fatalf("failed to load cassette: %s", err)
+	}
+	if status, replayed := send(player); status != http.StatusOK || !strings.Contains(replayed, base64Encode(SampleImage())) {
+		t.Errorf("unexpected replayed response: %d %s", status, replayed)
+	}
+
+	// interactions are replayed only once
+	req, _ := http.NewRequest("POST", s.URL+pathKarlo+"/upscale", strings.NewReader(body))
+	if _, err := player.RoundTrip(req); err == nil {
+		t.Errorf("should fail with no matching interaction")
+	}
+}
+
+func TestCassetteMultipart(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id":"multipart"}`))
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "cassette.json")
+
+	// multipart bodies with random boundaries, and the same fields and file
+	send := func(c *Cassette, file string) (int, error) {
+		body := &bytes.Buffer{}
+		writer := multipart.NewWriter(body)
+		writer.WriteField("threshold", "0.7")
+		part, _ := writer.CreateFormFile("image", "image.jpg")
+		part.Write([]byte(file))
+		writer.Close()
+
+		req, _ := http.NewRequest("POST", server.URL+"/v2/vision/face/detect", body)
+		req.Header.Set("Content-Type", writer.FormDataContentType())
+
+		resp, err := (&http.Client{Transport: c}).Do(req)
+		if err != nil {
+			return 0, err
+		}
+		defer resp.Body.Close()
+
+		return resp.StatusCode, nil
+	}
+
+	recorder, _ := NewCassette(path, CassetteModeRecord, nil)
+	if _, err := send(recorder, "image bytes"); err != nil {
+		t.Fatalf("failed to record: %s", err)
+	}
+	if err := recorder.Save(); err != nil {
+		t.Fatalf("failed to save cassette: %s", err)
+	}
+
+	player, err := NewCassette(path, CassetteModeReplay, nil)
+	if err != nil {
+		t.Fatalf("failed to load cassette: %s", err)
+	}
+	if _, err := send(player, "other image bytes"); err == nil {
+		t.Errorf("should not replay with a different file")
+	}
+	if status, err := send(player, "image bytes"); err != nil || status != http.StatusOK {
+		t.Errorf("failed to replay multipart request: %d, %v", status, err)
+	}
+}
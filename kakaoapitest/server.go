@@ -0,0 +1,427 @@
+// Package kakaoapitest provides a fake server of Kakao APIs for offline testing.
+//
+//	server := kakaoapitest.NewServer()
+//	defer server.Close()
+//
+//	client := kakaoapi.NewClient(server.APIKey,
+//		kakaoapi.WithBaseURL(kakaoapi.ServiceKoGPT, server.KoGPTURL()),
+//		kakaoapi.WithBaseURL(kakaoapi.ServiceKarlo, server.KarloURL()),
+//	)
+package kakaoapitest
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Endpoint is the name of each faked API endpoint
+type Endpoint string
+
+// Endpoints
+const (
+	EndpointKoGPTGeneration  Endpoint = "kogpt.generation"
+	EndpointKarloT2I         Endpoint = "karlo.t2i"
+	EndpointKarloUpscale     Endpoint = "karlo.upscale"
+	EndpointKarloVariations  Endpoint = "karlo.variations"
+	EndpointKarloNSFWChecker Endpoint = "karlo.nsfw_checker"
+)
+
+// paths of the endpoints
+const (
+	pathKoGPT  = "/v1/inference/kogpt"
+	pathKarlo  = "/v2/inference/karlo"
+	pathImages = "/images/"
+)
+
+var endpointPaths = map[string]Endpoint{
+	pathKoGPT + "/generation":   EndpointKoGPTGeneration,
+	pathKarlo + "/t2i":          EndpointKarloT2I,
+	pathKarlo + "/upscale":      EndpointKarloUpscale,
+	pathKarlo + "/variations":   EndpointKarloVariations,
+	pathKarlo + "/nsfw_checker": EndpointKarloNSFWChecker,
+}
+
+// DefaultAPIKey is the API key which the server accepts by default
+const DefaultAPIKey = "kakaoapitest-api-key"
+
+// Reply is a scripted response of the server
+type Reply struct {
+	Status int
+	Header http.Header
+	Body   []byte
+	Delay  time.Duration // delay before responding
+}
+
+// JSONReply returns a Reply with given status and value marshalled as JSON.
+func JSONReply(status int, v any) Reply {
+	body, err := json.Marshal(v)
+	if err != nil {
+		panic(fmt.Sprintf("kakaoapitest: failed to marshal reply: %s", err))
+	}
+
+	return Reply{
+		Status: status,
+		Header: http.Header{"Content-Type": []string{"application/json"}},
+		Body:   body,
+	}
+}
+
+// ErrorReply returns a Reply of Kakao API error with given status, code, and message.
+func ErrorReply(status, code int, msg string) Reply {
+	return JSONReply(status, map[string]any{
+		"code": code,
+		"msg":  msg,
+	})
+}
+
+// RecordedRequest is a request received by the server
+type RecordedRequest struct {
+	Endpoint Endpoint
+	Method   string
+	Path     string
+	Header   http.Header
+	Body     []byte
+	Params   map[string]any // JSON-decoded body (nil if not decodable)
+}
+
+// Server is a fake server of Kakao APIs
+type Server struct {
+	*httptest.Server
+
+	// API key to be accepted (any key is accepted if empty)
+	APIKey string
+
+	mu        sync.Mutex
+	scripts   map[Endpoint][]Reply
+	latencies map[Endpoint]time.Duration
+	requests  []RecordedRequest
+	images    map[string][]byte
+	sequence  int
+}
+
+// NewServer starts and returns a new fake server.
+//
+// It should be closed after use.
+func NewServer() *Server {
+	s := &Server{
+		APIKey:    DefaultAPIKey,
+		scripts:   map[Endpoint][]Reply{},
+		latencies: map[Endpoint]time.Duration{},
+		images:    map[string][]byte{},
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.handle))
+
+	return s
+}
+
+// KoGPTURL returns the base URL of faked KoGPT APIs.
+func (s *Server) KoGPTURL() string {
+	return s.URL + pathKoGPT
+}
+
+// KarloURL returns the base URL of faked Karlo APIs.
+func (s *Server) KarloURL() string {
+	return s.URL + pathKarlo
+}
+
+// Script queues given replies for the endpoint.
+//
+// Queued replies are returned in order before falling back to the default responses.
+func (s *Server) Script(endpoint Endpoint, replies ...Reply) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.scripts[endpoint] = append(s.scripts[endpoint], replies...)
+}
+
+// FailNext makes the next `times` requests of the endpoint fail with given status, code, and message.
+func (s *Server) FailNext(endpoint Endpoint, times, status, code int, msg string) {
+	for i := 0; i < times; i++ {
+		s.Script(endpoint, ErrorReply(status, code, msg))
+	}
+}
+
+// SetLatency delays all responses of the endpoint for given duration.
+func (s *Server) SetLatency(endpoint Endpoint, latency time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.latencies[endpoint] = latency
+}
+
+// Requests returns the recorded requests of the endpoint (all requests if `endpoint` is empty).
+func (s *Server) Requests(endpoint Endpoint) []RecordedRequest {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var requests []RecordedRequest
+	for _, req := range s.requests {
+		if endpoint == "" || req.Endpoint == endpoint {
+			requests = append(requests, req)
+		}
+	}
+
+	return requests
+}
+
+// Reset clears scripted replies, latencies, and recorded requests.
+func (s *Server) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.scripts = map[Endpoint][]Reply{}
+	s.latencies = map[Endpoint]time.Duration{}
+	s.requests = nil
+}
+
+// handles all requests to the server
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	// serve generated images
+	if strings.HasPrefix(r.URL.Path, pathImages) {
+		s.serveImage(w, r)
+		return
+	}
+
+	endpoint, exists := endpointPaths[r.URL.Path]
+	if !exists {
+		writeReply(w, ErrorReply(http.StatusNotFound, -3, fmt.Sprintf("unknown API: %s", r.URL.Path)))
+		return
+	}
+
+	body, _ := io.ReadAll(r.Body)
+	var params map[string]any
+	if err := json.Unmarshal(body, &params); err != nil {
+		params = nil
+	}
+
+	s.mu.Lock()
+	s.requests = append(s.requests, RecordedRequest{
+		Endpoint: endpoint,
+		Method:   r.Method,
+		Path:     r.URL.Path,
+		Header:   r.Header.Clone(),
+		Body:     body,
+		Params:   params,
+	})
+	latency := s.latencies[endpoint]
+	var reply *Reply
+	if replies := s.scripts[endpoint]; len(replies) > 0 {
+		reply, s.scripts[endpoint] = &replies[0], replies[1:]
+	}
+	s.mu.Unlock()
+
+	if reply != nil {
+		latency += reply.Delay
+	}
+	if latency > 0 {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-time.After(latency):
+		}
+	}
+
+	if reply != nil {
+		writeReply(w, *reply)
+		return
+	}
+
+	if len(s.APIKey) > 0 && r.Header.Get("Authorization") != "KakaoAK "+s.APIKey {
+		writeReply(w, ErrorReply(http.StatusUnauthorized, -401, "invalid API key"))
+		return
+	}
+	if r.Method != http.MethodPost || params == nil {
+		writeReply(w, ErrorReply(http.StatusBadRequest, -2, "invalid request"))
+		return
+	}
+
+	writeReply(w, s.defaultReply(r, endpoint, params))
+}
+
+// writes given reply to the response writer
+func writeReply(w http.ResponseWriter, reply Reply) {
+	for k, vs := range reply.Header {
+		for _, v := range vs {
+			w.Header().Add(k, v)
+		}
+	}
+	status := reply.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	w.WriteHeader(status)
+	w.Write(reply.Body)
+}
+
+// returns the default (successful) reply of the endpoint for given params
+func (s *Server) defaultReply(r *http.Request, endpoint Endpoint, params map[string]any) Reply {
+	id := s.nextID(string(endpoint))
+
+	switch endpoint {
+	case EndpointKoGPTGeneration:
+		n := intParam(params, "n", 1)
+		maxTokens := intParam(params, "max_tokens", 16)
+		prompt, _ := params["prompt"].(string)
+
+		generations := []map[string]any{}
+		for i := 0; i < n; i++ {
+			generations = append(generations, map[string]any{
+				"text":   fmt.Sprintf(" generated text #%d", i+1),
+				"tokens": maxTokens,
+			})
+		}
+		promptTokens := len([]rune(prompt))
+
+		return JSONReply(http.StatusOK, map[string]any{
+			"id":          id,
+			"generations": generations,
+			"usage": map[string]any{
+				"prompt_tokens":    promptTokens,
+				"generated_tokens": n * maxTokens,
+				"total_tokens":     promptTokens + n*maxTokens,
+			},
+		})
+	case EndpointKarloT2I, EndpointKarloVariations:
+		samples := intParam(params, "samples", 1)
+		seeds, _ := params["seed"].([]any)
+		nsfwChecker, _ := params["nsfw_checker"].(bool)
+
+		images := []map[string]any{}
+		for i := 0; i < samples; i++ {
+			seed := int64(i)
+			if i < len(seeds) {
+				if f, ok := seeds[i].(float64); ok {
+					seed = int64(f)
+				}
+			}
+
+			image := map[string]any{
+				"id":    fmt.Sprintf("%s-%d", id, i),
+				"seed":  seed,
+				"image": s.imageValue(r, params, fmt.Sprintf("%s-%d", id, i)),
+			}
+			if nsfwChecker {
+				image["nsfw_content_detected"] = false
+				image["nsfw_score"] = 0.01
+			}
+			images = append(images, image)
+		}
+
+		return JSONReply(http.StatusOK, map[string]any{
+			"id":            id,
+			"model_version": "kakaoapitest",
+			"images":        images,
+		})
+	case EndpointKarloUpscale:
+		sources, _ := params["images"].([]any)
+
+		images := []string{}
+		for i := range sources {
+			images = append(images, s.imageValue(r, params, fmt.Sprintf("%s-%d", id, i)))
+		}
+
+		return JSONReply(http.StatusOK, map[string]any{
+			"images": images,
+		})
+	case EndpointKarloNSFWChecker:
+		sources, _ := params["images"].([]any)
+
+		results := []map[string]any{}
+		for range sources {
+			results = append(results, map[string]any{
+				"nsfw_content_detected": false,
+				"nsfw_score":            0.01,
+			})
+		}
+
+		return JSONReply(http.StatusOK, map[string]any{
+			"id":            id,
+			"model_version": "kakaoapitest",
+			"results":       results,
+		})
+	}
+
+	return ErrorReply(http.StatusNotFound, -3, fmt.Sprintf("unknown endpoint: %s", endpoint))
+}
+
+// returns an URL or a base64-encoded string of a generated image, depending on the `return_type` param
+func (s *Server) imageValue(r *http.Request, params map[string]any, id string) string {
+	generated := SampleImage()
+
+	if returnType, _ := params["return_type"].(string); returnType == "url" {
+		s.mu.Lock()
+		s.images[id] = generated
+		s.mu.Unlock()
+
+		return fmt.Sprintf("http://%s%s%s.png", r.Host, pathImages, id)
+	}
+
+	return base64Encode(generated)
+}
+
+// serves generated images
+func (s *Server) serveImage(w http.ResponseWriter, r *http.Request) {
+	id := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, pathImages), ".png")
+
+	s.mu.Lock()
+	image, exists := s.images[id]
+	s.mu.Unlock()
+
+	if !exists {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "image/png")
+	w.Write(image)
+}
+
+// returns a new sequential ID with given prefix
+func (s *Server) nextID(prefix string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sequence++
+
+	return fmt.Sprintf("%s-%d", strings.ReplaceAll(prefix, ".", "-"), s.sequence)
+}
+
+// returns an int param from JSON-decoded params
+func intParam(params map[string]any, key string, defaultValue int) int {
+	if value, ok := params[key].(float64); ok {
+		return int(value)
+	}
+
+	return defaultValue
+}
+
+// SampleImage returns the bytes of a small png image, which is used for faked image responses.
+func SampleImage() []byte {
+	img := image.NewRGBA(image.Rect(0, 0, 8, 8))
+	for x := 0; x < 8; x++ {
+		for y := 0; y < 8; y++ {
+			img.Set(x, y, color.RGBA{R: uint8(x * 32), G: uint8(y * 32), B: 128, A: 255})
+		}
+	}
+
+	var buf bytes.Buffer
+	png.Encode(&buf, img)
+
+	return buf.Bytes()
+}
+
+// encodes given bytes into a base64-encoded string
+func base64Encode(bytes []byte) string {
+	return base64.StdEncoding.EncodeToString(bytes)
+}
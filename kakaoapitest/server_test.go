@@ -0,0 +1,63 @@
+package kakaoapitest
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// posts given JSON body to the path of the server
+func post(t *testing.T, s *Server, path, body string) (int, map[string]any) {
+	t.Helper()
+
+	req, _ := http.NewRequest("POST", s.URL+path, strings.NewReader(body))
+	req.Header.Set("Authorization", "KakaoAK "+s.APIKey)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("failed to post: %s", err)
+	}
+	defer resp.Body.Close()
+
+	var decoded map[string]any
+	bytes, _ := io.ReadAll(resp.Body)
+	json.Unmarshal(bytes, &decoded)
+
+	return resp.StatusCode, decoded
+}
+
+func TestScript(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	s.Script(EndpointKarloT2I,
+		ErrorReply(http.StatusTooManyRequests, -10, "quota exceeded"),
+		JSONReply(http.StatusOK, map[string]any{"id": "scripted"}),
+	)
+
+	if status, body := post(t, s, pathKarlo+"/t2i", `{"prompt":"test"}`); status != http.StatusTooManyRequests || body["code"] != float64(-10) {
+		t.Errorf("unexpected first reply: %d %v", status, body)
+	}
+	if status, body := post(t, s, pathKarlo+"/t2i", `{"prompt":"test"}`); status != http.StatusOK || body["id"] != "scripted" {
+		t.Errorf("unexpected second reply: %d %v", status, body)
+	}
+	if status, body := post(t, s, pathKarlo+"/t2i", `{"prompt":"test","samples":2,"return_type":"url"}`); status != http.StatusOK {
+		t.Errorf("unexpected default reply: %d %v", status, body)
+	} else if images, _ := body["images"].([]any); len(images) != 2 {
+		t.Errorf("unexpected count of images: %v", body)
+	} else {
+		url := images[0].(map[string]any)["image"].(string)
+		if resp, err := http.Get(url); err != nil || resp.StatusCode != http.StatusOK {
+			t.Errorf("failed to fetch generated image: %v", err)
+		} else {
+			resp.Body.Close()
+		}
+	}
+
+	s.AssertCalled(t, EndpointKarloT2I, 3)
+	s.AssertParam(t, EndpointKarloT2I, "samples", 2)
+
+	s.Reset()
+	s.AssertCalled(t, EndpointKarloT2I, 0)
+}
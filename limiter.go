@@ -0,0 +1,196 @@
+package kakaoapi
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// RateLimiter throttles API calls with per-API token buckets
+// and caps the number of in-flight requests.
+type RateLimiter struct {
+	mu      sync.Mutex
+	buckets map[APIName]*tokenBucket
+
+	semaphore chan struct{} // nil if the number of in-flight requests is not capped
+
+	waiting     int
+	inFlight    int
+	waits       int64
+	waitedTotal time.Duration
+}
+
+// RateLimiterStats is the snapshot of a RateLimiter's metrics
+type RateLimiterStats struct {
+	Waiting     int           // number of requests currently waiting for their turns
+	InFlight    int           // number of requests currently in flight
+	Waits       int64         // number of requests which had to wait so far
+	WaitedTotal time.Duration // total time requests waited so far
+}
+
+// token bucket for a single API
+type tokenBucket struct {
+	rate   float64 // tokens per second
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// NewRateLimiter returns a new RateLimiter which allows at most `maxInFlight` requests at once.
+//
+// The number of in-flight requests is not capped if `maxInFlight` <= 0.
+func NewRateLimiter(maxInFlight int) *RateLimiter {
+	l := &RateLimiter{
+		buckets: map[APIName]*tokenBucket{},
+	}
+	if maxInFlight > 0 {
+		l.semaphore = make(chan struct{}, maxInFlight)
+	}
+
+	return l
+}
+
+// SetLimit limits requests of given API to `ratePerSecond`, allowing bursts of up to `burst` requests.
+func (l *RateLimiter) SetLimit(api APIName, ratePerSecond float64, burst int) *RateLimiter {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if burst < 1 {
+		burst = 1
+	}
+	l.buckets[api] = &tokenBucket{
+		rate:   ratePerSecond,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+
+	return l
+}
+
+// Stats returns the current metrics of the rate limiter.
+func (l *RateLimiter) Stats() RateLimiterStats {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return RateLimiterStats{
+		Waiting:     l.waiting,
+		InFlight:    l.inFlight,
+		Waits:       l.waits,
+		WaitedTotal: l.waitedTotal,
+	}
+}
+
+// WithRateLimiter sets the rate limiter of the client.
+func WithRateLimiter(limiter *RateLimiter) ClientOption {
+	return func(c *Client) {
+		c.limiter = limiter
+	}
+}
+
+// waits for the turn of given API's request, and returns a function for releasing it
+//
+// Returns the context's error if it is canceled while waiting.
+func (l *RateLimiter) acquire(ctx context.Context, api APIName) (release func(), err error) {
+	if l == nil {
+		return func() {}, nil
+	}
+
+	started := time.Now()
+	waited := false
+
+	l.mu.Lock()
+	l.waiting++
+	wait := l.reserve(api, started)
+	l.mu.Unlock()
+
+	defer func() {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+
+		l.waiting--
+		if err == nil {
+			l.inFlight++
+			if waited {
+				l.waits++
+				l.waitedTotal += time.Since(started)
+			}
+		} else {
+			l.cancelReservation(api)
+		}
+	}()
+
+	// wait for a token
+	if wait > 0 {
+		waited = true
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+
+	// wait for a free slot
+	if l.semaphore != nil {
+		select {
+		case l.semaphore <- struct{}{}:
+		default:
+			waited = true
+
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case l.semaphore <- struct{}{}:
+			}
+		}
+	}
+
+	return func() {
+		l.mu.Lock()
+		l.inFlight--
+		l.mu.Unlock()
+
+		if l.semaphore != nil {
+			<-l.semaphore
+		}
+	}, nil
+}
+
+// takes a token from given API's bucket and returns the duration to wait for it
+//
+// (should be called with the lock held)
+func (l *RateLimiter) reserve(api APIName, now time.Time) time.Duration {
+	bucket, exists := l.buckets[api]
+	if !exists || bucket.rate <= 0 {
+		return 0
+	}
+
+	// refill tokens
+	bucket.tokens += now.Sub(bucket.last).Seconds() * bucket.rate
+	if bucket.tokens > bucket.burst {
+		bucket.tokens = bucket.burst
+	}
+	bucket.last = now
+
+	bucket.tokens--
+	if bucket.tokens >= 0 {
+		return 0
+	}
+
+	return time.Duration(-bucket.tokens / bucket.rate * float64(time.Second))
+}
+
+// returns a reserved token to given API's bucket
+//
+// (should be called with the lock held)
+func (l *RateLimiter) cancelReservation(api APIName) {
+	if bucket, exists := l.buckets[api]; exists {
+		bucket.tokens++
+		if bucket.tokens > bucket.burst {
+			bucket.tokens = bucket.burst
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package kakaoapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRateLimiter(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	limiter := NewRateLimiter(0).SetLimit(APIKarloT2I, 10, 1)
+	client := NewClient("test-api-key", WithBaseURL(ServiceKarlo, server.URL), WithRateLimiter(limiter))
+
+	started := time.Now()
+	for i := 0; i < 4; i++ {
+		if _, err := client.GenerateImages(NewParamsImageGeneration("test")); err != nil {
+			t.Fatalf("failed to generate images: %s", err)
+		}
+	}
+	if elapsed := time.Since(started); elapsed < 250*time.Millisecond {
+		t.Errorf("requests were not throttled: %s", elapsed)
+	}
+	if stats := limiter.Stats(); stats.Waits != 3 || stats.InFlight != 0 || stats.Waiting != 0 {
+		t.Errorf("unexpected stats: %+v", stats)
+	}
+
+	// other APIs are not throttled
+	started = time.Now()
+	for i := 0; i < 4; i++ {
+		if _, err := client.UpscaleImages(NewParamsImageUpscale([]string{"test"})); err != nil {
+			t.Fatalf("failed to upscale images: %s", err)
+		}
+	}
+	if elapsed := time.Since(started); elapsed > 250*time.Millisecond {
+		t.Errorf("requests were throttled unexpectedly: %s", elapsed)
+	}
+
+	// canceled while waiting
+	limiter.SetLimit(APIKarloT2I, 0.1, 1)
+	client.GenerateImages(NewParamsImageGeneration("test")) // consumes the only token
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := client.GenerateImagesWithContext(ctx, NewParamsImageGeneration("test")); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("should fail with deadline exceeded: %v", err)
+	}
+}
+
+func TestRateLimiterMaxInFlight(t *testing.T) {
+	var inFlight, maxInFlight int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+
+		for {
+			max := atomic.LoadInt32(&maxInFlight)
+			if current <= max || atomic.CompareAndSwapInt32(&maxInFlight, max, current) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	limiter := NewRateLimiter(2)
+	client := NewClient("test-api-key", WithBaseURL(ServiceKarlo, server.URL), WithRateLimiter(limiter))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			if _, err := client.CheckNSFW([]string{"test"}); err != nil {
+				t.Errorf("failed to check NSFW: %s", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if maxInFlight > 2 {
+		t.Errorf("too many requests were in flight: %d", maxInFlight)
+	}
+	if stats := limiter.Stats(); stats.Waits == 0 || stats.InFlight != 0 {
+		t.Errorf("unexpected stats: %+v", stats)
+	}
+}
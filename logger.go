@@ -0,0 +1,141 @@
+package kakaoapi
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+)
+
+// Logger is the interface for logging (verbose) messages of the client
+//
+// `args` are alternating key-value pairs of attributes,
+// so `*slog.Logger` satisfies this interface.
+type Logger interface {
+	Debug(msg string, args ...any)
+	Info(msg string, args ...any)
+	Warn(msg string, args ...any)
+	Error(msg string, args ...any)
+}
+
+// LogLevel is the level of log messages
+type LogLevel int
+
+// LogLevels (same values with `slog.Level`s)
+const (
+	LogLevelDebug LogLevel = -4
+	LogLevelInfo  LogLevel = 0
+	LogLevelWarn  LogLevel = 4
+	LogLevelError LogLevel = 8
+)
+
+// String returns the string representation of LogLevel.
+func (l LogLevel) String() string {
+	switch {
+	case l < LogLevelInfo:
+		return "DEBUG"
+	case l < LogLevelWarn:
+		return "INFO"
+	case l < LogLevelError:
+		return "WARN"
+	default:
+		return "ERROR"
+	}
+}
+
+// stdLogger is a Logger which writes messages with `*log.Logger`
+type stdLogger struct {
+	logger *log.Logger
+	level  LogLevel
+}
+
+// NewStdLogger returns a new Logger which writes messages of `level` or higher with given `*log.Logger`.
+func NewStdLogger(logger *log.Logger, level LogLevel) Logger {
+	return &stdLogger{
+		logger: logger,
+		level:  level,
+	}
+}
+
+// Debug logs a message at debug level.
+func (l *stdLogger) Debug(msg string, args ...any) {
+	l.log(LogLevelDebug, msg, args...)
+}
+
+// Info logs a message at info level.
+func (l *stdLogger) Info(msg string, args ...any) {
+	l.log(LogLevelInfo, msg, args...)
+}
+
+// Warn logs a message at warn level.
+func (l *stdLogger) Warn(msg string, args ...any) {
+	l.log(LogLevelWarn, msg, args...)
+}
+
+// Error logs a message at error level.
+func (l *stdLogger) Error(msg string, args ...any) {
+	l.log(LogLevelError, msg, args...)
+}
+
+// formats and writes given message with attributes as: `LEVEL msg key1=value1 key2=value2 ...`
+func (l *stdLogger) log(level LogLevel, msg string, args ...any) {
+	if level < l.level {
+		return
+	}
+
+	var sb strings.Builder
+	sb.WriteString(level.String())
+	sb.WriteString(" ")
+	sb.WriteString(msg)
+	for i := 0; i < len(args); i += 2 {
+		if i+1 < len(args) {
+			sb.WriteString(fmt.Sprintf(" %v=%q", args[i], fmt.Sprintf("%v", args[i+1])))
+		} else {
+			sb.WriteString(fmt.Sprintf(" !BADKEY=%q", fmt.Sprintf("%v", args[i])))
+		}
+	}
+
+	l.logger.Print(sb.String())
+}
+
+const (
+	redactedText = "[REDACTED]"
+
+	maxBase64Length     = 64 // base64-encoded strings longer than this will be truncated in logs
+	truncatedBase64Head = 16 // number of leading characters kept in truncated base64 strings
+)
+
+var (
+	authHeaderRegex = regexp.MustCompile(`(?im)^(Authorization:\s*\S+)\s+\S+`)
+	formSecretRegex = regexp.MustCompile(`\b((?:access_token|refresh_token|id_token|client_secret|code|code_verifier)=)[^&\s]+`)
+	jsonSecretRegex = regexp.MustCompile(`("(?:access_token|refresh_token|id_token)"\s*:\s*)"[^"]*"`)
+	base64Regex     = regexp.MustCompile(fmt.Sprintf(`[A-Za-z0-9+/]{%d,}={0,2}`, maxBase64Length+1))
+)
+
+// redacts credentials and truncates base64-encoded images in given string for logging
+func (c *Client) redact(str string) string {
+	for _, key := range []string{c.apiKey, c.adminKey} {
+		if len(key) > 0 {
+			str = strings.ReplaceAll(str, key, redactedText)
+		}
+	}
+	str = authHeaderRegex.ReplaceAllString(str, "$1 "+redactedText)
+	str = formSecretRegex.ReplaceAllString(str, "${1}"+redactedText)
+	str = jsonSecretRegex.ReplaceAllString(str, `${1}"`+redactedText+`"`)
+
+	return base64Regex.ReplaceAllStringFunc(str, func(encoded string) string {
+		return fmt.Sprintf("%s...(%d chars truncated)", encoded[:truncatedBase64Head], len(encoded)-truncatedBase64Head)
+	})
+}
+
+// generates a random ID for identifying requests in logs
+func newRequestID() string {
+	bytes := make([]byte, 8)
+	if _, err := rand.Read(bytes); err != nil {
+		return "unknown"
+	}
+
+	return hex.EncodeToString(bytes)
+}
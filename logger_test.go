@@ -0,0 +1,58 @@
+package kakaoapi
+
+import (
+	"bytes"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestLoggerRedaction(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id":"test","images":[{"image":"` + strings.Repeat("QUJD", 1000) + `"}]}`))
+	}))
+	defer server.Close()
+
+	buffer := &bytes.Buffer{}
+	apiKey := "secret-api-key-0123456789"
+	client := NewClient(apiKey,
+		WithBaseURL(ServiceKarlo, server.URL),
+		WithLogger(NewStdLogger(log.New(buffer, "", 0), LogLevelDebug)),
+		WithVerbose(true),
+	)
+
+	if _, err := client.GenerateImages(NewParamsImageGeneration("test")); err != nil {
+		t.Fatalf("failed to generate images: %s", err)
+	}
+
+	logged := buffer.String()
+	if strings.Contains(logged, apiKey) {
+		t.Errorf("API key was not redacted: %s", logged)
+	}
+	if !strings.Contains(logged, redactedText) {
+		t.Errorf("authorization header was not redacted: %s", logged)
+	}
+	if strings.Contains(logged, strings.Repeat("QUJD", 100)) {
+		t.Errorf("base64 image was not truncated: %s", logged)
+	}
+	for _, attr := range []string{"request_id=", "method=\"POST\"", "path=\"/t2i\"", "status=\"200\"", "latency="} {
+		if !strings.Contains(logged, attr) {
+			t.Errorf("attribute `%s` was not logged: %s", attr, logged)
+		}
+	}
+}
+
+func TestStdLoggerLevel(t *testing.T) {
+	buffer := &bytes.Buffer{}
+	logger := NewStdLogger(log.New(buffer, "", 0), LogLevelWarn)
+
+	logger.Debug("debug message")
+	logger.Info("info message")
+	logger.Warn("warn message", "key", "value")
+
+	if logged := buffer.String(); logged != "WARN warn message key=\"value\"\n" {
+		t.Errorf("unexpected log output: %s", logged)
+	}
+}
@@ -0,0 +1,69 @@
+package kakaoapi
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+// APIName is the name of each API (eg. "karlo.t2i", "kogpt.generation")
+type APIName string
+
+// Call describes an API call which is passed through middlewares
+type Call struct {
+	API     APIName           // name of the API
+	Method  string            // HTTP method
+	URL     string            // URL of the API
+	Headers map[string]string // additional HTTP headers
+	Params  any               // parameters (`map[string]any` for GET, any value for others)
+
+	Deterministic bool // whether the same params always produce the same response (cacheable)
+
+	authType authType
+}
+
+// path returns the path of the call's URL.
+func (c *Call) path() string {
+	if u, err := url.Parse(c.URL); err == nil {
+		return u.Path
+	}
+
+	return c.URL
+}
+
+// Response is the HTTP response of an API call
+type Response struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+}
+
+// RoundTrip is a function which performs an API call and returns its response
+//
+// For non-successful responses, both the response and an error (*APIError) are returned.
+type RoundTrip func(ctx context.Context, call *Call) (*Response, error)
+
+// Middleware wraps a RoundTrip for cross-cutting concerns (eg. metrics, tracing, caching)
+//
+// A middleware can short-circuit the call by returning a synthetic response without calling `next`.
+type Middleware func(next RoundTrip) RoundTrip
+
+// WithMiddlewares appends given middlewares to the client.
+//
+// Middlewares are run in the order of addition (the first one is the outermost),
+// once per API call (retries happen inside the innermost RoundTrip).
+func WithMiddlewares(middlewares ...Middleware) ClientOption {
+	return func(c *Client) {
+		c.middlewares = append(c.middlewares, middlewares...)
+	}
+}
+
+// returns the RoundTrip which runs all middlewares around the (cached) HTTP round trip
+func (c *Client) chain() RoundTrip {
+	roundTrip := c.cached(c.send)
+	for i := len(c.middlewares) - 1; i >= 0; i-- {
+		roundTrip = c.middlewares[i](roundTrip)
+	}
+
+	return roundTrip
+}
@@ -0,0 +1,72 @@
+package kakaoapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestMiddlewareOrder(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id":"from-server"}`))
+	}))
+	defer server.Close()
+
+	var trace []string
+	tracer := func(name string) Middleware {
+		return func(next RoundTrip) RoundTrip {
+			return func(ctx context.Context, call *Call) (*Response, error) {
+				trace = append(trace, name+">"+string(call.API))
+				resp, err := next(ctx, call)
+				trace = append(trace, name+"<"+string(call.API))
+				return resp, err
+			}
+		}
+	}
+
+	client := NewClient("test-api-key",
+		WithBaseURL(ServiceKarlo, server.URL),
+		WithMiddlewares(tracer("first"), tracer("second")),
+	)
+
+	if generated, err := client.GenerateImages(NewParamsImageGeneration("test")); err != nil {
+		t.Fatalf("failed to generate images: %s", err)
+	} else if generated.ID != "from-server" {
+		t.Errorf("unexpected response: %+v", generated)
+	}
+
+	if traced := strings.Join(trace, ","); traced != "first>karlo.t2i,second>karlo.t2i,second<karlo.t2i,first<karlo.t2i" {
+		t.Errorf("middlewares were not run in order: %s", traced)
+	}
+}
+
+func TestMiddlewareShortCircuit(t *testing.T) {
+	synthetic := func(status int, body string) Middleware {
+		return func(next RoundTrip) RoundTrip {
+			return func(ctx context.Context, call *Call) (*Response, error) {
+				return &Response{StatusCode: status, Body: []byte(body)}, nil
+			}
+		}
+	}
+
+	// (base URL is not reachable, so requests will fail if not short-circuited)
+	client := NewClient("test-api-key",
+		WithBaseURL(ServiceKoGPT, "http://127.0.0.1:0"),
+		WithMiddlewares(synthetic(http.StatusOK, `{"id":"synthetic"}`)),
+	)
+	if generated, err := client.GenerateTexts(NewParamsTextGeneration("test", 10)); err != nil {
+		t.Errorf("failed to generate texts: %s", err)
+	} else if generated.ID != "synthetic" {
+		t.Errorf("unexpected response: %+v", generated)
+	}
+
+	client = NewClient("test-api-key",
+		WithBaseURL(ServiceKoGPT, "http://127.0.0.1:0"),
+		WithMiddlewares(synthetic(http.StatusTooManyRequests, `{"code":-10,"msg":"quota exceeded"}`)),
+	)
+	if _, err := client.GenerateTexts(NewParamsTextGeneration("test", 10)); !IsQuotaExceeded(err) {
+		t.Errorf("synthetic error response should be converted to an API error: %v", err)
+	}
+}
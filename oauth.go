@@ -0,0 +1,328 @@
+package kakaoapi
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// API names of Kakao Login APIs
+const (
+	APIOAuthToken     APIName = "oauth.token"
+	APIOAuthTokenInfo APIName = "oauth.token_info"
+	APIOAuthLogout    APIName = "oauth.logout"
+)
+
+// default time before expiry when access tokens are refreshed
+const defaultTokenRefreshLeeway = 5 * time.Minute
+
+// time to wait before retrying a failed refresh of a still-valid access token
+const tokenRefreshBackoff = 30 * time.Second
+
+var (
+	// ErrNoTokenSource is returned when an API which needs a user's access token is called without a token source.
+	ErrNoTokenSource = errors.New("no token source for Bearer-authenticated API")
+
+	// ErrNoRefreshToken is returned when an expired access token cannot be refreshed.
+	ErrNoRefreshToken = errors.New("access token expired and no valid refresh token")
+)
+
+// TokenSource provides access tokens for Bearer-authenticated APIs
+//
+// Implementations must be safe for concurrent use.
+type TokenSource interface {
+	// Token returns a valid token.
+	Token(ctx context.Context) (OAuthToken, error)
+}
+
+// WithClientSecret sets the client secret for Kakao Login, if it is enabled for the app.
+func WithClientSecret(secret string) ClientOption {
+	return func(c *Client) {
+		c.clientSecret = secret
+	}
+}
+
+// WithTokenSource sets the token source for Bearer-authenticated APIs.
+func WithTokenSource(source TokenSource) ClientOption {
+	return func(c *Client) {
+		c.tokenSource = source
+	}
+}
+
+// WithOAuthToken sets a token source which refreshes given token with the client before it expires.
+//
+// `onRefresh` (if not nil) is called with each refreshed token, eg. for persisting it.
+func WithOAuthToken(token OAuthToken, onRefresh func(OAuthToken)) ClientOption {
+	return func(c *Client) {
+		c.tokenSource = &refreshingTokenSource{
+			client:    c,
+			token:     token,
+			leeway:    defaultTokenRefreshLeeway,
+			onRefresh: onRefresh,
+		}
+	}
+}
+
+// StaticTokenSource returns a token source which always returns given access token.
+func StaticTokenSource(accessToken string) TokenSource {
+	return staticTokenSource{token: OAuthToken{TokenType: string(authTypeBearer), AccessToken: accessToken}}
+}
+
+type staticTokenSource struct {
+	token OAuthToken
+}
+
+// Token returns the static token.
+func (s staticTokenSource) Token(ctx context.Context) (OAuthToken, error) {
+	return s.token, nil
+}
+
+// token source which refreshes its token before expiry
+type refreshingTokenSource struct {
+	mu        sync.Mutex
+	client    *Client
+	token     OAuthToken
+	leeway    time.Duration
+	onRefresh func(OAuthToken)
+
+	retryAfter time.Time // refresh is not retried until this time after a failure
+}
+
+// Token returns the current token, refreshing it first if it expires soon.
+func (s *refreshingTokenSource) Token(ctx context.Context) (OAuthToken, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token.Expiry.IsZero() || time.Now().Add(s.leeway).Before(s.token.Expiry) {
+		return s.token, nil
+	}
+
+	if len(s.token.RefreshToken) <= 0 ||
+		(!s.token.RefreshTokenExpiry.IsZero() && time.Now().After(s.token.RefreshTokenExpiry)) {
+		if time.Now().Before(s.token.Expiry) {
+			return s.token, nil // (still valid for a while)
+		}
+		return OAuthToken{}, ErrNoRefreshToken
+	}
+
+	// (back off after a failed refresh, while the current token is still valid)
+	now := time.Now()
+	if now.Before(s.retryAfter) && now.Before(s.token.Expiry) {
+		return s.token, nil
+	}
+
+	refreshed, err := s.client.RefreshToken(ctx, s.token.RefreshToken)
+	if err != nil {
+		if now := time.Now(); now.Before(s.token.Expiry) {
+			s.retryAfter = now.Add(tokenRefreshBackoff)
+			return s.token, nil // (still valid, so refresh again after the backoff)
+		}
+		return OAuthToken{}, err
+	}
+	s.retryAfter = time.Time{}
+
+	// refresh token is renewed only when it expires soon, so keep the current one otherwise
+	if len(refreshed.RefreshToken) <= 0 {
+		refreshed.RefreshToken = s.token.RefreshToken
+		refreshed.RefreshTokenExpiresIn = s.token.RefreshTokenExpiresIn
+		refreshed.RefreshTokenExpiry = s.token.RefreshTokenExpiry
+	}
+	s.token = refreshed
+
+	if s.onRefresh != nil {
+		s.onRefresh(refreshed)
+	}
+
+	return refreshed, nil
+}
+
+// PKCE is the code verifier and challenge for the authorization code flow with PKCE
+type PKCE struct {
+	Verifier  string // to be sent with the code exchange
+	Challenge string // to be sent with the authorization request
+	Method    string // always "S256"
+}
+
+// NewPKCE generates a new random PKCE code verifier and its challenge.
+func NewPKCE() (PKCE, error) {
+	bytes := make([]byte, 32)
+	if _, err := rand.Read(bytes); err != nil {
+		return PKCE{}, err
+	}
+	verifier := base64.RawURLEncoding.EncodeToString(bytes)
+	hash := sha256.Sum256([]byte(verifier))
+
+	return PKCE{
+		Verifier:  verifier,
+		Challenge: base64.RawURLEncoding.EncodeToString(hash[:]),
+		Method:    "S256",
+	}, nil
+}
+
+// AuthorizeURL returns the URL of Kakao Login where users should be redirected for authorization.
+//
+// https://developers.kakao.com/docs/latest/ko/kakaologin/rest-api#request-code
+func (c *Client) AuthorizeURL(params ParamsAuthorization) (string, error) {
+	if err := params.Validate(); err != nil {
+		return "", err
+	}
+
+	query := url.Values{}
+	query.Set("client_id", c.apiKey)
+	query.Set("redirect_uri", params.RedirectURI)
+	query.Set("response_type", "code")
+	if len(params.Scopes) > 0 {
+		query.Set("scope", strings.Join(params.Scopes, ","))
+	}
+	if params.State != nil {
+		query.Set("state", *params.State)
+	}
+	if len(params.Prompts) > 0 {
+		query.Set("prompt", strings.Join(params.Prompts, ","))
+	}
+	if params.LoginHint != nil {
+		query.Set("login_hint", *params.LoginHint)
+	}
+	if params.Nonce != nil {
+		query.Set("nonce", *params.Nonce)
+	}
+	if params.CodeChallenge != nil {
+		query.Set("code_challenge", *params.CodeChallenge)
+	}
+	if params.CodeChallengeMethod != nil {
+		query.Set("code_challenge_method", *params.CodeChallengeMethod)
+	}
+
+	return c.baseURL(ServiceKakaoAuth) + "/oauth/authorize?" + query.Encode(), nil
+}
+
+// ExchangeCode exchanges given authorization code for tokens.
+//
+// `codeVerifier` should be the PKCE verifier if the authorization was requested with PKCE, or empty otherwise.
+//
+// https://developers.kakao.com/docs/latest/ko/kakaologin/rest-api#request-token
+func (c *Client) ExchangeCode(ctx context.Context, code, redirectURI, codeVerifier string) (OAuthToken, error) {
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", redirectURI)
+	if len(codeVerifier) > 0 {
+		form.Set("code_verifier", codeVerifier)
+	}
+
+	return c.requestToken(ctx, form)
+}
+
+// RefreshToken issues new tokens with given refresh token.
+//
+// The returned refresh token is empty unless the given one was about to expire.
+//
+// https://developers.kakao.com/docs/latest/ko/kakaologin/rest-api#refresh-token
+func (c *Client) RefreshToken(ctx context.Context, refreshToken string) (OAuthToken, error) {
+	form := url.Values{}
+	form.Set("grant_type", "refresh_token")
+	form.Set("refresh_token", refreshToken)
+
+	return c.requestToken(ctx, form)
+}
+
+// requests tokens with given form, which is completed with the client's credentials
+func (c *Client) requestToken(ctx context.Context, form url.Values) (token OAuthToken, err error) {
+	form.Set("client_id", c.apiKey)
+	if len(c.clientSecret) > 0 {
+		form.Set("client_secret", c.clientSecret)
+	}
+
+	var bytes []byte
+	bytes, err = c.post(ctx, APIOAuthToken, c.baseURL(ServiceKakaoAuth)+"/oauth/token", authTypeNone, nil, form)
+
+	if err == nil {
+		err = json.Unmarshal(bytes, &token)
+		if err == nil {
+			now := time.Now()
+			if token.ExpiresIn > 0 {
+				token.Expiry = now.Add(time.Duration(token.ExpiresIn) * time.Second)
+			}
+			if token.RefreshTokenExpiresIn > 0 {
+				token.RefreshTokenExpiry = now.Add(time.Duration(token.RefreshTokenExpiresIn) * time.Second)
+			}
+			return token, nil
+		} else if c.Verbose {
+			c.logger.Error("failed to decode bytes while requesting tokens", "bytes", c.redact(string(bytes)))
+		}
+	}
+
+	return OAuthToken{}, err
+}
+
+// TokenInfo returns the information of the current access token.
+//
+// https://developers.kakao.com/docs/latest/ko/kakaologin/rest-api#get-token-info
+func (c *Client) TokenInfo(ctx context.Context) (res ResponseTokenInfo, err error) {
+	var bytes []byte
+	bytes, err = c.get(ctx, APIOAuthTokenInfo, c.baseURL(ServiceKakaoAPI)+"/v1/user/access_token_info", authTypeBearer, nil, nil)
+
+	if err == nil {
+		err = json.Unmarshal(bytes, &res)
+		if err == nil {
+			return res, nil
+		} else if c.Verbose {
+			c.logger.Error("failed to decode bytes while fetching token info", "bytes", c.redact(string(bytes)))
+		}
+	}
+
+	return ResponseTokenInfo{}, err
+}
+
+// Logout expires the current access and refresh tokens.
+//
+// https://developers.kakao.com/docs/latest/ko/kakaologin/rest-api#logout
+func (c *Client) Logout(ctx context.Context) (res ResponseUserID, err error) {
+	return c.postForUserID(ctx, APIOAuthLogout, "/v1/user/logout", "logging out")
+}
+
+// Unlink disconnects the current user from the app.
+//
+// https://developers.kakao.com/docs/latest/ko/kakaologin/rest-api#unlink
+func (c *Client) Unlink(ctx context.Context) (res ResponseUserID, err error) {
+	return c.postForUserID(ctx, APIUserUnlink, "/v1/user/unlink", "unlinking")
+}
+
+// posts to given Bearer-authenticated path, which responds with a user's ID
+func (c *Client) postForUserID(ctx context.Context, api APIName, path, action string) (res ResponseUserID, err error) {
+	var bytes []byte
+	bytes, err = c.post(ctx, api, c.baseURL(ServiceKakaoAPI)+path, authTypeBearer, nil, url.Values{})
+
+	if err == nil {
+		err = json.Unmarshal(bytes, &res)
+		if err == nil {
+			return res, nil
+		} else if c.Verbose {
+			c.logger.Error("failed to decode bytes while "+action, "bytes", c.redact(string(bytes)))
+		}
+	}
+
+	return ResponseUserID{}, err
+}
+
+// Validate checks if the parameters have a redirect URI and a valid PKCE method.
+func (p ParamsAuthorization) Validate() error {
+	v := &validator{}
+
+	v.checkString("redirect_uri", p.RedirectURI, true, 0)
+	if p.CodeChallenge != nil && len(*p.CodeChallenge) <= 0 {
+		v.fail("code_challenge", "required when set")
+	}
+	if p.CodeChallengeMethod != nil && *p.CodeChallengeMethod != "S256" && *p.CodeChallengeMethod != "plain" {
+		v.fail("code_challenge_method", "'%s' is not one of S256, plain", *p.CodeChallengeMethod)
+	}
+
+	return v.err()
+}
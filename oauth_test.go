@@ -0,0 +1,243 @@
+package kakaoapi
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// returns a stub server of Kakao Login APIs which issues `access-<n>` tokens,
+// and accepts only the latest access token for Bearer-authenticated APIs
+func newOAuthServer(t *testing.T) (server *httptest.Server, issued *int32) {
+	issued = new(int32)
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/oauth/token":
+			if r.Header.Get("Authorization") != "" {
+				t.Errorf("token request should not have an authorization header")
+			}
+			if contentType := r.Header.Get("Content-Type"); !strings.HasPrefix(contentType, "application/x-www-form-urlencoded") {
+				t.Errorf("unexpected content type: %s", contentType)
+			}
+			r.ParseForm()
+			if r.PostForm.Get("client_id") != "test-api-key" {
+				t.Errorf("unexpected client_id: %s", r.PostForm.Get("client_id"))
+			}
+
+			switch r.PostForm.Get("grant_type") {
+			case "authorization_code":
+				if r.PostForm.Get("code") != "valid-code" {
+					w.WriteHeader(http.StatusBadRequest)
+					w.Write([]byte(`{"error":"invalid_grant","error_description":"authorization code not found","error_code":"KOE320"}`))
+					return
+				}
+			case "refresh_token":
+				if r.PostForm.Get("refresh_token") != "refresh" {
+					w.WriteHeader(http.StatusBadRequest)
+					w.Write([]byte(`{"error":"invalid_grant","error_description":"invalid refresh token","error_code":"KOE322"}`))
+					return
+				}
+			}
+
+			n := atomic.AddInt32(issued, 1)
+			refresh := ""
+			if n == 1 {
+				refresh = `,"refresh_token":"refresh","refresh_token_expires_in":5184000`
+			}
+			w.Write([]byte(`{"token_type":"bearer","access_token":"access-` + string(rune('0'+n)) + `","expires_in":1` + refresh + `}`))
+		case "/v1/user/access_token_info", "/v1/user/logout":
+			if r.Header.Get("Authorization") != "Bearer access-"+string(rune('0'+atomic.LoadInt32(issued))) {
+				w.WriteHeader(http.StatusUnauthorized)
+				w.Write([]byte(`{"code":-401,"msg":"this access token does not exist"}`))
+				return
+			}
+			w.Write([]byte(`{"id":1234,"expires_in":1,"app_id":5678}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	return server, issued
+}
+
+func TestAuthorizeURL(t *testing.T) {
+	client := NewClient("test-api-key")
+
+	pkce, err := NewPKCE()
+	if err != nil {
+		t.Fatalf("failed to generate PKCE: %s", err)
+	}
+	if len(pkce.Verifier) != 43 || pkce.Method != "S256" {
+		t.Errorf("unexpected PKCE: %+v", pkce)
+	}
+
+	authorizeURL, err := client.AuthorizeURL(NewParamsAuthorization("https://example.com/callback").
+		SetScopes("profile_nickname", "talk_message").
+		SetState("xyz").
+		SetPKCE(pkce))
+	if err != nil {
+		t.Fatalf("failed to build authorize URL: %s", err)
+	}
+
+	parsed, _ := url.Parse(authorizeURL)
+	if parsed.Host != "kauth.kakao.com" || parsed.Path != "/oauth/authorize" {
+		t.Errorf("unexpected authorize URL: %s", authorizeURL)
+	}
+	for key, expected := range map[string]string{
+		"client_id":             "test-api-key",
+		"redirect_uri":          "https://example.com/callback",
+		"response_type":         "code",
+		"scope":                 "profile_nickname,talk_message",
+		"state":                 "xyz",
+		"code_challenge":        pkce.Challenge,
+		"code_challenge_method": "S256",
+	} {
+		if value := parsed.Query().Get(key); value != expected {
+			t.Errorf("unexpected value of %s: %s (expected: %s)", key, value, expected)
+		}
+	}
+
+	if _, err := client.AuthorizeURL(NewParamsAuthorization("")); err == nil {
+		t.Errorf("should have failed without redirect URI")
+	}
+}
+
+func TestOAuthTokens(t *testing.T) {
+	server, issued := newOAuthServer(t)
+	defer server.Close()
+
+	client := NewClient("test-api-key",
+		WithBaseURL(ServiceKakaoAuth, server.URL),
+		WithBaseURL(ServiceKakaoAPI, server.URL),
+	)
+	ctx := context.Background()
+
+	// Bearer-authenticated APIs need a token source
+	if _, err := client.TokenInfo(ctx); !errors.Is(err, ErrNoTokenSource) {
+		t.Errorf("expected ErrNoTokenSource, got: %v", err)
+	}
+
+	// invalid code
+	if _, err := client.ExchangeCode(ctx, "invalid-code", "https://example.com/callback", ""); !IsInvalidGrant(err) {
+		t.Errorf("expected invalid grant error, got: %v", err)
+	} else if apiErr := AsAPIError(err); apiErr.OAuthErrorCode != "KOE320" {
+		t.Errorf("unexpected error code: %s", apiErr.OAuthErrorCode)
+	}
+
+	token, err := client.ExchangeCode(ctx, "valid-code", "https://example.com/callback", "verifier")
+	if err != nil {
+		t.Fatalf("failed to exchange code: %s", err)
+	}
+	if token.AccessToken != "access-1" || token.RefreshToken != "refresh" || token.Expiry.IsZero() || token.RefreshTokenExpiry.IsZero() {
+		t.Errorf("unexpected token: %+v", token)
+	}
+
+	// (access token expires within the leeway, so it is refreshed before the call)
+	var refreshed []OAuthToken
+	userClient := NewClient("test-api-key",
+		WithBaseURL(ServiceKakaoAuth, server.URL),
+		WithBaseURL(ServiceKakaoAPI, server.URL),
+		WithOAuthToken(token, func(token OAuthToken) {
+			refreshed = append(refreshed, token)
+		}),
+	)
+	if info, err := userClient.TokenInfo(ctx); err != nil {
+		t.Errorf("failed to fetch token info: %s", err)
+	} else if info.ID != 1234 || info.AppID != 5678 {
+		t.Errorf("unexpected token info: %+v", info)
+	}
+	if len(refreshed) != 1 || refreshed[0].AccessToken != "access-2" || refreshed[0].RefreshToken != "refresh" {
+		t.Errorf("unexpected refreshed tokens: %+v", refreshed)
+	}
+
+	if res, err := userClient.Logout(ctx); err != nil {
+		t.Errorf("failed to log out: %s", err)
+	} else if res.ID != 1234 {
+		t.Errorf("unexpected logout response: %+v", res)
+	}
+	if n := atomic.LoadInt32(issued); n != 3 {
+		t.Errorf("expected 3 issued tokens, got %d", n)
+	}
+
+	// expired access token without refresh token
+	expiredClient := NewClient("test-api-key",
+		WithBaseURL(ServiceKakaoAPI, server.URL),
+		WithOAuthToken(OAuthToken{AccessToken: "expired", Expiry: time.Now().Add(-time.Second)}, nil),
+	)
+	if _, err := expiredClient.TokenInfo(ctx); !errors.Is(err, ErrNoRefreshToken) {
+		t.Errorf("expected ErrNoRefreshToken, got: %v", err)
+	}
+
+	// failed refresh falls back to the current token until it actually expires
+	for expiry, shouldFail := range map[time.Duration]bool{time.Minute: false, -time.Second: true} {
+		failingClient := NewClient("test-api-key",
+			WithBaseURL(ServiceKakaoAuth, server.URL),
+			WithOAuthToken(OAuthToken{AccessToken: "current", RefreshToken: "invalid", Expiry: time.Now().Add(expiry)}, nil),
+		)
+		if token, err := failingClient.tokenSource.Token(ctx); shouldFail && !IsInvalidGrant(err) {
+			t.Errorf("expected invalid grant error with expired token, got: %v", err)
+		} else if !shouldFail && (err != nil || token.AccessToken != "current") {
+			t.Errorf("expected the current token, got: %+v, %v", token, err)
+		}
+	}
+
+	// failed refresh is not retried until the backoff passes
+	var refreshes int32
+	failingServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&refreshes, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer failingServer.Close()
+	backoffClient := NewClient("test-api-key",
+		WithBaseURL(ServiceKakaoAuth, failingServer.URL),
+		WithOAuthToken(OAuthToken{AccessToken: "current", RefreshToken: "refresh", Expiry: time.Now().Add(time.Minute)}, nil),
+	)
+	for i := 0; i < 3; i++ {
+		if token, err := backoffClient.tokenSource.Token(ctx); err != nil || token.AccessToken != "current" {
+			t.Errorf("expected the current token, got: %+v, %v", token, err)
+		}
+	}
+	if n := atomic.LoadInt32(&refreshes); n != 1 {
+		t.Errorf("expected 1 refresh attempt within the backoff, got %d", n)
+	}
+
+	// static tokens are sent as they are
+	staticClient := NewClient("test-api-key",
+		WithBaseURL(ServiceKakaoAPI, server.URL),
+		WithTokenSource(StaticTokenSource("stale")),
+	)
+	if _, err := staticClient.TokenInfo(ctx); !IsInvalidAPIKey(err) {
+		t.Errorf("expected unauthorized error, got: %v", err)
+	}
+}
+
+func TestOAuthRedaction(t *testing.T) {
+	server, _ := newOAuthServer(t)
+	defer server.Close()
+
+	buffer := &bytes.Buffer{}
+	client := NewClient("test-api-key",
+		WithBaseURL(ServiceKakaoAuth, server.URL),
+		WithClientSecret("client-secret"),
+		WithLogger(NewStdLogger(log.New(buffer, "", 0), LogLevelDebug)),
+		WithVerbose(true),
+	)
+	if _, err := client.ExchangeCode(context.Background(), "valid-code", "https://example.com/callback", ""); err != nil {
+		t.Fatalf("failed to exchange code: %s", err)
+	}
+
+	logged := buffer.String()
+	for _, secret := range []string{"valid-code", "client-secret", "access-1", `"refresh"`} {
+		if strings.Contains(logged, secret) {
+			t.Errorf("secret %s was not redacted: %s", secret, logged)
+		}
+	}
+}
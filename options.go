@@ -0,0 +1,81 @@
+package kakaoapi
+
+import (
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Service is the type of Kakao API services
+type Service string
+
+// Services
+const (
+	ServiceKoGPT Service = "kogpt"
+	ServiceKarlo Service = "karlo"
+
+	ServiceKakaoAuth Service = "kauth" // Kakao Login (kauth.kakao.com)
+	ServiceKakaoAPI  Service = "kapi"  // Kakao APIs for users, messages, and friends (kapi.kakao.com)
+	ServiceDapi      Service = "dapi"  // Kakao APIs for local, search, translation, and vision (dapi.kakao.com)
+)
+
+// ClientOption is the type of options for NewClient
+type ClientOption func(*Client)
+
+// WithHTTPClient sets the HTTP client to be used for requests.
+func WithHTTPClient(httpClient *http.Client) ClientOption {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithBaseURL sets the base URL of given service.
+//
+// (eg. for pointing the client at a local stand-in server)
+func WithBaseURL(service Service, baseURL string) ClientOption {
+	return func(c *Client) {
+		c.baseURLs[service] = strings.TrimSuffix(baseURL, "/")
+	}
+}
+
+// WithTimeout sets the timeout of each HTTP request, including the time to receive response headers.
+func WithTimeout(timeout time.Duration) ClientOption {
+	return func(c *Client) {
+		c.timeout = timeout
+	}
+}
+
+// WithUserAgent sets the `User-Agent` header of requests.
+func WithUserAgent(userAgent string) ClientOption {
+	return func(c *Client) {
+		c.userAgent = userAgent
+	}
+}
+
+// WithLogger sets the logger for (verbose) messages.
+func WithLogger(logger Logger) ClientOption {
+	return func(c *Client) {
+		c.logger = logger
+	}
+}
+
+// WithRetryPolicy sets the retry policy for failed requests.
+func WithRetryPolicy(policy *RetryPolicy) ClientOption {
+	return func(c *Client) {
+		c.Retry = policy
+	}
+}
+
+// WithVerbose sets the client to log verbose messages or not.
+func WithVerbose(verbose bool) ClientOption {
+	return func(c *Client) {
+		c.Verbose = verbose
+	}
+}
+
+// WithAdminKey sets the admin key of the app, for APIs which need it (eg. listing app users).
+func WithAdminKey(adminKey string) ClientOption {
+	return func(c *Client) {
+		c.adminKey = adminKey
+	}
+}
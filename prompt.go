@@ -0,0 +1,113 @@
+package kakaoapi
+
+import (
+	"context"
+	"unicode"
+)
+
+// PromptTranslator translates prompts for Karlo
+//
+// *Client implements it with the Kakao translation API.
+type PromptTranslator interface {
+	Translate(ctx context.Context, text string, src, target LanguageCode) (string, error)
+}
+
+// TranslatedPrompts is the record of prompts translated before generating images
+type TranslatedPrompts struct {
+	Prompt                   string  `json:"prompt"`
+	TranslatedPrompt         string  `json:"translated_prompt"`
+	NegativePrompt           *string `json:"negative_prompt,omitempty"`
+	TranslatedNegativePrompt *string `json:"translated_negative_prompt,omitempty"`
+}
+
+// prompt translation settings of the client
+type promptTranslation struct {
+	translator PromptTranslator
+	cache      Cache
+}
+
+// WithPromptTranslation makes GenerateImages and VaryImage translate Korean prompts
+// (`prompt` and `negative_prompt` with any Hangul in them) into English before sending.
+//
+// The client's own translation API is used if `translator` is nil,
+// and translations are cached in memory if `cache` is nil.
+func WithPromptTranslation(translator PromptTranslator, cache Cache) ClientOption {
+	return func(c *Client) {
+		if cache == nil {
+			cache = NewMemoryCache(1000)
+		}
+
+		c.promptTranslation = &promptTranslation{
+			translator: translator,
+			cache:      cache,
+		}
+	}
+}
+
+// translates given prompts if prompt translation is enabled and they contain Hangul,
+// and returns the record of translation (nil if nothing was translated)
+func (c *Client) translatePrompts(ctx context.Context, prompt string, negativePrompt *string) (translatedPrompt string, translatedNegativePrompt *string, record *TranslatedPrompts, err error) {
+	translatedPrompt, translatedNegativePrompt = prompt, negativePrompt
+	if c.promptTranslation == nil {
+		return translatedPrompt, translatedNegativePrompt, nil, nil
+	}
+
+	translated := false
+	if containsHangul(prompt) {
+		if translatedPrompt, err = c.translatePrompt(ctx, prompt); err != nil {
+			return prompt, negativePrompt, nil, err
+		}
+		translated = true
+	}
+	if negativePrompt != nil && containsHangul(*negativePrompt) {
+		var negative string
+		if negative, err = c.translatePrompt(ctx, *negativePrompt); err != nil {
+			return prompt, negativePrompt, nil, err
+		}
+		translatedNegativePrompt = &negative
+		translated = true
+	}
+
+	if translated {
+		record = &TranslatedPrompts{
+			Prompt:                   prompt,
+			TranslatedPrompt:         translatedPrompt,
+			NegativePrompt:           negativePrompt,
+			TranslatedNegativePrompt: translatedNegativePrompt,
+		}
+	}
+
+	return translatedPrompt, translatedNegativePrompt, record, nil
+}
+
+// translates given Korean text into English, using the cache
+func (c *Client) translatePrompt(ctx context.Context, text string) (string, error) {
+	key := string(LanguageKorean) + ">" + string(LanguageEnglish) + ":" + text
+	if cached, ok := c.promptTranslation.cache.Get(key); ok {
+		return string(cached), nil
+	}
+
+	var translator PromptTranslator = c
+	if c.promptTranslation.translator != nil {
+		translator = c.promptTranslation.translator
+	}
+
+	translated, err := translator.Translate(ctx, text, LanguageKorean, LanguageEnglish)
+	if err != nil {
+		return "", err
+	}
+	c.promptTranslation.cache.Set(key, []byte(translated), 0)
+
+	return translated, nil
+}
+
+// checks if given text has any Hangul in it
+func containsHangul(text string) bool {
+	for _, r := range text {
+		if unicode.Is(unicode.Hangul, r) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,140 @@
+package kakaoapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+// translator which counts translations and prefixes texts with `en:`
+type countingTranslator struct {
+	translations int32
+}
+
+func (t *countingTranslator) Translate(ctx context.Context, text string, src, target LanguageCode) (string, error) {
+	atomic.AddInt32(&t.translations, 1)
+	return "en:" + text, nil
+}
+
+func TestPromptTranslation(t *testing.T) {
+	var prompts []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("failed to decode request body: %s", err)
+		}
+		prompt, _ := body["prompt"].(string)
+		negative, _ := body["negative_prompt"].(string)
+		prompts = append(prompts, prompt+"|"+negative)
+
+		w.Write([]byte(`{"id":"generated","images":[{"id":"image"}]}`))
+	}))
+	defer server.Close()
+
+	translator := &countingTranslator{}
+	client := NewClient("test-api-key",
+		WithBaseURL(ServiceKarlo, server.URL),
+		WithPromptTranslation(translator, nil),
+	)
+
+	// Korean prompts are translated, and the translations are cached
+	params := NewParamsImageGeneration("고양이").SetNegativePrompt("흐릿한")
+	for i := 0; i < 2; i++ {
+		generated, err := client.GenerateImages(params)
+		if err != nil {
+			t.Fatalf("failed to generate images: %s", err)
+		}
+
+		record := generated.TranslatedPrompts
+		if record == nil || record.Prompt != "고양이" || record.TranslatedPrompt != "en:고양이" ||
+			*record.NegativePrompt != "흐릿한" || *record.TranslatedNegativePrompt != "en:흐릿한" {
+			t.Errorf("unexpected translated prompts: %+v", record)
+		}
+	}
+	if n := atomic.LoadInt32(&translator.translations); n != 2 {
+		t.Errorf("expected 2 translations, got %d", n)
+	}
+	if *params.NegativePrompt != "흐릿한" {
+		t.Errorf("params should not have been modified: %s", *params.NegativePrompt)
+	}
+
+	// English prompts are sent as they are
+	if varied, err := client.VaryImage(NewParamsImageVariation("aW1hZ2U=", "a cat").SetNegativePrompt("blurry")); err != nil {
+		t.Fatalf("failed to vary image: %s", err)
+	} else if varied.TranslatedPrompts != nil {
+		t.Errorf("English prompts should not have been translated: %+v", varied.TranslatedPrompts)
+	}
+
+	// invalid params are rejected before translation
+	before := atomic.LoadInt32(&translator.translations)
+	if _, err := client.GenerateImages(NewParamsImageGeneration("강아지").SetWidth(100)); err == nil {
+		t.Errorf("should have failed with invalid width")
+	}
+	if _, err := client.VaryImage(NewParamsImageVariation("", "강아지")); err == nil {
+		t.Errorf("should have failed without image")
+	}
+	if n := atomic.LoadInt32(&translator.translations); n != before {
+		t.Errorf("invalid params should not have been translated")
+	}
+
+	// prompts' lengths are checked after translation
+	if _, err := client.GenerateImages(NewParamsImageGeneration(strings.Repeat("가", karloMaxPromptLength-1))); err == nil {
+		t.Errorf("should have failed with too long translated prompt")
+	}
+
+	expected := []string{"en:고양이|en:흐릿한", "en:고양이|en:흐릿한", "a cat|blurry"}
+	if strings.Join(prompts, ",") != strings.Join(expected, ",") {
+		t.Errorf("unexpected prompts sent: %v", prompts)
+	}
+}
+
+func TestPromptTranslationWithTranslationAPI(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v2/translation/translate":
+			r.ParseForm()
+			if r.PostForm.Get("src_lang") != "kr" || r.PostForm.Get("target_lang") != "en" {
+				t.Errorf("unexpected form: %v", r.PostForm)
+			}
+			w.Write([]byte(`{"translated_text":[["a cat"]]}`))
+		default:
+			var body map[string]any
+			json.NewDecoder(r.Body).Decode(&body)
+			if body["prompt"] != "a cat" {
+				t.Errorf("unexpected prompt: %v", body["prompt"])
+			}
+			w.Write([]byte(`{"id":"generated","images":[{"id":"image"}]}`))
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key",
+		WithBaseURL(ServiceKarlo, server.URL),
+		WithBaseURL(ServiceDapi, server.URL),
+		WithPromptTranslation(nil, nil),
+	)
+
+	if generated, err := client.GenerateImages(NewParamsImageGeneration("고양이")); err != nil {
+		t.Fatalf("failed to generate images: %s", err)
+	} else if generated.TranslatedPrompts == nil || generated.TranslatedPrompts.TranslatedPrompt != "a cat" || generated.TranslatedPrompts.NegativePrompt != nil {
+		t.Errorf("unexpected translated prompts: %+v", generated.TranslatedPrompts)
+	}
+}
+
+func TestContainsHangul(t *testing.T) {
+	for text, expected := range map[string]bool{
+		"a cat":      false,
+		"고양이":        true,
+		"a cat, 귀여운": true,
+		"ㄱ":          true,
+		"猫 (ねこ)":     false,
+	} {
+		if containsHangul(text) != expected {
+			t.Errorf("unexpected result for %q", text)
+		}
+	}
+}
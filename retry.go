@@ -0,0 +1,121 @@
+package kakaoapi
+
+import (
+	"errors"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy is the policy for retrying failed requests.
+type RetryPolicy struct {
+	MaxAttempts int           // maximum number of attempts, including the first one
+	BaseBackoff time.Duration // backoff before the first retry, doubled on each retry
+	MaxBackoff  time.Duration // upper limit of the backoff (no limit if 0)
+	Jitter      float64       // ratio (0.0 ~ 1.0) of the backoff to be randomized
+
+	RetryableStatuses []int // HTTP statuses which are retryable
+	RetryableCodes    []int // Kakao error codes which are retryable
+
+	RetryOnNetworkErrors bool // retry on network errors (eg. connection reset) or not
+	RespectRetryAfter    bool // wait for `Retry-After` header's value (if given) instead of the backoff
+}
+
+// NewRetryPolicy returns a new RetryPolicy with sensible default values:
+// retries on HTTP 429/5xx and Kakao's internal/maintenance errors.
+func NewRetryPolicy(maxAttempts int) *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts: maxAttempts,
+		BaseBackoff: 500 * time.Millisecond,
+		MaxBackoff:  30 * time.Second,
+		Jitter:      0.2,
+		RetryableStatuses: []int{
+			http.StatusTooManyRequests,
+			http.StatusInternalServerError,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		},
+		RetryableCodes: []int{
+			ErrorCodeInternal,
+			ErrorCodeServiceMaintenance,
+		},
+		RetryOnNetworkErrors: true,
+		RespectRetryAfter:    true,
+	}
+}
+
+// checks if a request should be retried after `attempt` attempts which ended with `err`
+func (p *RetryPolicy) shouldRetry(attempt int, err error) bool {
+	if p == nil || attempt >= p.MaxAttempts || err == nil {
+		return false
+	}
+
+	// never retry canceled or timed-out requests
+	var ctxErr *ContextError
+	if errors.As(err, &ctxErr) {
+		return false
+	}
+
+	if apiErr := AsAPIError(err); apiErr != nil {
+		for _, status := range p.RetryableStatuses {
+			if apiErr.StatusCode == status {
+				return true
+			}
+		}
+		for _, code := range p.RetryableCodes {
+			if apiErr.Code != 0 && apiErr.Code == code {
+				return true
+			}
+		}
+		return false
+	}
+
+	return p.RetryOnNetworkErrors
+}
+
+// returns the duration to wait before the next attempt
+func (p *RetryPolicy) backoff(attempt int, err error) time.Duration {
+	if p.RespectRetryAfter {
+		if apiErr := AsAPIError(err); apiErr != nil && apiErr.RetryAfter > 0 {
+			return apiErr.RetryAfter
+		}
+	}
+
+	backoff := p.BaseBackoff
+	for i := 1; i < attempt; i++ {
+		backoff *= 2
+
+		if p.MaxBackoff > 0 && backoff >= p.MaxBackoff {
+			break
+		}
+	}
+	if p.MaxBackoff > 0 && backoff > p.MaxBackoff {
+		backoff = p.MaxBackoff
+	}
+
+	if p.Jitter > 0 {
+		backoff -= time.Duration(rand.Float64() * p.Jitter * float64(backoff))
+	}
+
+	return backoff
+}
+
+// parses the value of `Retry-After` header (in delay-seconds or HTTP-date)
+func parseRetryAfter(value string) time.Duration {
+	if len(value) <= 0 {
+		return 0
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		if delay := time.Until(date); delay > 0 {
+			return delay
+		}
+	}
+
+	return 0
+}
@@ -0,0 +1,162 @@
+package kakaoapi
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// returns a test server which fails `failures` times with given status before succeeding
+func newFlakyServer(t *testing.T, failures int32, status int, header map[string]string) (*httptest.Server, *int32) {
+	var attempts int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if !strings.Contains(string(body), "test prompt") {
+			t.Errorf("request body was not rebuilt properly: %s", string(body))
+		}
+
+		if atomic.AddInt32(&attempts, 1) <= failures {
+			for k, v := range header {
+				w.Header().Set(k, v)
+			}
+			w.WriteHeader(status)
+			w.Write([]byte(`{"code":-1,"msg":"internal error"}`))
+			return
+		}
+
+		w.Write([]byte(`{"id":"test"}`))
+	}))
+
+	return server, &attempts
+}
+
+func testRetryPolicy(maxAttempts int) *RetryPolicy {
+	policy := NewRetryPolicy(maxAttempts)
+	policy.BaseBackoff = 10 * time.Millisecond
+	policy.MaxBackoff = 50 * time.Millisecond
+
+	return policy
+}
+
+func TestRetry(t *testing.T) {
+	server, attempts := newFlakyServer(t, 2, http.StatusServiceUnavailable, nil)
+	defer server.Close()
+
+	client := NewClient("test-api-key")
+	client.Retry = testRetryPolicy(3)
+
+	// application/json
+	if _, err := client.post(context.Background(), "test", server.URL, authTypeKakaoAK, nil, map[string]any{"prompt": "test prompt"}); err != nil {
+		t.Errorf("should succeed after retries: %s", err)
+	}
+	if *attempts != 3 {
+		t.Errorf("should have attempted 3 times, but attempted %d times", *attempts)
+	}
+
+	// multipart/form-data
+	atomic.StoreInt32(attempts, 0)
+	if _, err := client.post(context.Background(), "test", server.URL, authTypeKakaoAK, nil, map[string]any{
+		"prompt": "test prompt",
+		"image":  newFileParamFromBytes([]byte("not an image")),
+	}); err != nil {
+		t.Errorf("should succeed after retries: %s", err)
+	}
+	if *attempts != 3 {
+		t.Errorf("should have attempted 3 times, but attempted %d times", *attempts)
+	}
+}
+
+func TestRetryMultipartAnsweredEarly(t *testing.T) {
+	image := []byte(strings.Repeat("x", 4*1024*1024))
+
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// fails without reading the body, while the file is still being written
+		if atomic.AddInt32(&attempts, 1) < 3 {
+			w.Header().Set("Connection", "close")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		if file, _, err := r.FormFile("image"); err != nil {
+			t.Errorf("no image in the request: %s", err)
+		} else if bytes, _ := io.ReadAll(file); len(bytes) != len(image) {
+			t.Errorf("image was truncated: %d bytes", len(bytes))
+		}
+		w.Write([]byte(`{"id":"test"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key")
+	client.Retry = testRetryPolicy(3)
+
+	file := newFileParamFromBytes(image)
+	defer file.Close()
+	if _, err := client.post(context.Background(), "test", server.URL, authTypeKakaoAK, nil, map[string]any{"image": file}); err != nil {
+		t.Errorf("should succeed after retries: %s", err)
+	}
+}
+
+func TestRetryExhausted(t *testing.T) {
+	server, attempts := newFlakyServer(t, 5, http.StatusTooManyRequests, nil)
+	defer server.Close()
+
+	client := NewClient("test-api-key")
+	client.Retry = testRetryPolicy(2)
+
+	if _, err := client.post(context.Background(), "test", server.URL, authTypeKakaoAK, nil, map[string]any{"prompt": "test prompt"}); !IsRateLimited(err) {
+		t.Errorf("should fail with the last API error: %s", err)
+	}
+	if *attempts != 2 {
+		t.Errorf("should have attempted 2 times, but attempted %d times", *attempts)
+	}
+}
+
+func TestRetryNotRetryable(t *testing.T) {
+	server, attempts := newFlakyServer(t, 1, http.StatusBadRequest, nil)
+	defer server.Close()
+
+	client := NewClient("test-api-key")
+	client.Retry = testRetryPolicy(3)
+	client.Retry.RetryableCodes = nil
+
+	if _, err := client.post(context.Background(), "test", server.URL, authTypeKakaoAK, nil, map[string]any{"prompt": "test prompt"}); err == nil {
+		t.Errorf("should fail without retrying")
+	}
+	if *attempts != 1 {
+		t.Errorf("should have attempted only once, but attempted %d times", *attempts)
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	server, attempts := newFlakyServer(t, 1, http.StatusTooManyRequests, map[string]string{"Retry-After": "1"})
+	defer server.Close()
+
+	client := NewClient("test-api-key")
+	client.Retry = testRetryPolicy(2)
+
+	started := time.Now()
+	if _, err := client.post(context.Background(), "test", server.URL, authTypeKakaoAK, nil, map[string]any{"prompt": "test prompt"}); err != nil {
+		t.Errorf("should succeed after retries: %s", err)
+	}
+	if elapsed := time.Since(started); elapsed < time.Second {
+		t.Errorf("should have waited for `Retry-After`, but retried after %s", elapsed)
+	}
+	if *attempts != 2 {
+		t.Errorf("should have attempted 2 times, but attempted %d times", *attempts)
+	}
+
+	// canceled while waiting
+	atomic.StoreInt32(attempts, 0)
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	if _, err := client.post(ctx, "test", server.URL, authTypeKakaoAK, nil, map[string]any{"prompt": "test prompt"}); err == nil || ctx.Err() == nil {
+		t.Errorf("should fail with context error: %v", err)
+	}
+}
@@ -0,0 +1,70 @@
+package kakaoapi
+
+import "unicode"
+
+// number of characters per token in runs of each character class
+const (
+	charsPerTokenLatin = 4
+	charsPerTokenDigit = 2
+)
+
+// character classes for token estimation
+type charClass int
+
+const (
+	charClassNone charClass = iota
+	charClassSpace
+	charClassLatin
+	charClassDigit
+	charClassOther
+)
+
+// EstimateTokens returns the approximate number of KoGPT tokens of given text.
+//
+// It is not an exact tokenizer, but errs on the side of overestimation:
+// each Hangul syllable (or CJK character) counts as a token,
+// runs of Latin letters and digits count as 1 token per 4 and 2 characters respectively,
+// and every other symbol counts as a token. Whitespaces are merged into the following tokens.
+func EstimateTokens(text string) (tokens int) {
+	class, runLength := charClassNone, 0
+
+	// counts tokens of the current run of characters
+	flush := func() {
+		switch class {
+		case charClassLatin:
+			tokens += (runLength + charsPerTokenLatin - 1) / charsPerTokenLatin
+		case charClassDigit:
+			tokens += (runLength + charsPerTokenDigit - 1) / charsPerTokenDigit
+		}
+		class, runLength = charClassNone, 0
+	}
+
+	for _, r := range text {
+		var current charClass
+		switch {
+		case unicode.IsSpace(r):
+			current = charClassSpace
+		case r < unicode.MaxASCII && unicode.IsLetter(r):
+			current = charClassLatin
+		case unicode.IsDigit(r):
+			current = charClassDigit
+		default:
+			current = charClassOther
+		}
+
+		if current != class {
+			flush()
+		}
+
+		switch current {
+		case charClassLatin, charClassDigit:
+			class = current
+			runLength++
+		case charClassOther: // Hangul, CJK, symbols, ...
+			tokens++
+		}
+	}
+	flush()
+
+	return tokens
+}
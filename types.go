@@ -1,6 +1,18 @@
 package kakaoapi
 
-import "os"
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"io"
+	"io/fs"
+	"math"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
 
 ///////////////////////////////
 // types, structs, and functions for HTTP
@@ -9,33 +21,109 @@ import "os"
 type authType string
 
 const (
+	authTypeNone    authType = "" // no `Authorization` header
 	authTypeBearer  authType = "Bearer"
 	authTypeKakaoAK authType = "KakaoAK"
+
+	authTypeAdminKey authType = "KakaoAK(admin)" // `KakaoAK` with the admin key
 )
 
 // file parameter struct for HTTP POST/PUT
+//
+// Its content is streamed from the reader, and rewound on retries if the reader is seekable.
 type fileParam struct {
-	bytes []byte
+	reader io.Reader
+	closer io.Closer // closer of the reader (nil if not needed)
+
+	mu      sync.Mutex
+	offset  int64  // initial offset of the seekable reader (-1 if not seekable)
+	started bool   // whether the reader has been read or not
+	stop    func() // stops the writer of the last request and waits for it to return (nil if none)
 }
 
 // newFileParamFromBytes creates a new fileParam from given bytes
-func newFileParamFromBytes(bytes []byte) fileParam {
-	return fileParam{
-		bytes: bytes,
+func newFileParamFromBytes(b []byte) *fileParam {
+	return newFileParamFromReader(bytes.NewReader(b))
+}
+
+// newFileParamFromReader creates a new fileParam from given reader
+func newFileParamFromReader(reader io.Reader) *fileParam {
+	file := &fileParam{
+		reader: reader,
+		offset: -1,
+	}
+	if seeker, ok := reader.(io.Seeker); ok {
+		if offset, err := seeker.Seek(0, io.SeekCurrent); err == nil {
+			file.offset = offset
+		}
 	}
+
+	return file
+}
+
+// newFileParamFromFile creates a new fileParam from given file, which will be closed with the fileParam
+func newFileParamFromFile(f fs.File) *fileParam {
+	file := newFileParamFromReader(f)
+	file.closer = f
+
+	return file
 }
 
 // newFileParamFromFilepath creates a new fileParam from given file location
-func newFileParamFromFilepath(path string) (fileParam, error) {
-	var bytes []byte
-	var err error
-	if bytes, err = os.ReadFile(path); err == nil {
-		return fileParam{
-			bytes: bytes,
-		}, nil
+//
+// The returned fileParam should be closed after use.
+func newFileParamFromFilepath(path string) (*fileParam, error) {
+	f, err := os.Open(path)
+	if err == nil {
+		return newFileParamFromFile(f), nil
+	}
+
+	return nil, err
+}
+
+// rewinds the reader of the fileParam for a new request which will be stopped with `stop`,
+// or returns an error if it was already read and is not seekable
+//
+// The writer of the last request is stopped before rewinding, so it never reads the reader concurrently.
+func (f *fileParam) rewind(stop func()) (err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.stop != nil {
+		f.stop()
+		f.stop = nil
+	}
+
+	if f.started {
+		if seeker, ok := f.reader.(io.Seeker); ok && f.offset >= 0 {
+			_, err = seeker.Seek(f.offset, io.SeekStart)
+		} else {
+			err = fmt.Errorf("file parameter cannot be rewound for retry, for its reader is not seekable")
+		}
+	}
+	if err == nil {
+		f.started = true
+		f.stop = stop
+	}
+
+	return err
+}
+
+// Close stops the writer of the last request (if any), and closes the underlying file (if any).
+func (f *fileParam) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.stop != nil {
+		f.stop()
+		f.stop = nil
 	}
 
-	return fileParam{}, err
+	if f.closer != nil {
+		return f.closer.Close()
+	}
+
+	return nil
 }
 
 ///////////////////////////////
@@ -46,37 +134,53 @@ func newFileParamFromFilepath(path string) (fileParam, error) {
 type ResponseError struct {
 	Code int    `json:"code,omitempty"`
 	Msg  string `json:"msg,omitempty"`
+
+	// some APIs (eg. Local, Daum Search) respond with these fields instead
+	ErrorType string `json:"errorType,omitempty"`
+	Message   string `json:"message,omitempty"`
+
+	// Kakao Login APIs respond with these fields instead
+	Error            string `json:"error,omitempty"`
+	ErrorDescription string `json:"error_description,omitempty"`
+	ErrorCode        string `json:"error_code,omitempty"`
 }
 
 ///////////////////////////////
 // API request & response structs
 //
 
-type ParamsTextGeneration map[string]any
+// ParamsTextGeneration is the parameters for text generation
+type ParamsTextGeneration struct {
+	Prompt      string   `json:"prompt"`
+	MaxTokens   int      `json:"max_tokens"`
+	Temperature *float64 `json:"temperature,omitempty"`
+	TopP        *float64 `json:"top_p,omitempty"`
+	N           *int     `json:"n,omitempty"`
+}
 
 // NewParamsTextGeneration creates a new ParamsTextGeneration.
 func NewParamsTextGeneration(prompt string, maxTokens int) ParamsTextGeneration {
 	return ParamsTextGeneration{
-		"prompt":     prompt,
-		"max_tokens": maxTokens,
+		Prompt:    prompt,
+		MaxTokens: maxTokens,
 	}
 }
 
 // SetTemp sets the temperature of ParamsTextGeneration.
 func (p ParamsTextGeneration) SetTemp(temp float64) ParamsTextGeneration {
-	p["temperature"] = temp
+	p.Temperature = &temp
 	return p
 }
 
 // SetTopP sets the top_p of ParamsTextGeneration.
 func (p ParamsTextGeneration) SetTopP(topP float64) ParamsTextGeneration {
-	p["top_p"] = topP
+	p.TopP = &topP
 	return p
 }
 
 // SetN sets the n of ParamsTextGeneration.
 func (p ParamsTextGeneration) SetN(n int) ParamsTextGeneration {
-	p["n"] = n
+	p.N = &n
 	return p
 }
 
@@ -116,108 +220,127 @@ const (
 	ImageDecodeSchedulerDDPM ImageDecodeScheduler = "decoder_ddpm_v_prediction"
 )
 
-type ParamsImageGeneration map[string]any
+// ParamsImageGeneration is the parameters for image generation
+type ParamsImageGeneration struct {
+	Prompt                 string                `json:"prompt"`
+	NegativePrompt         *string               `json:"negative_prompt,omitempty"`
+	Width                  *int                  `json:"width,omitempty"`
+	Height                 *int                  `json:"height,omitempty"`
+	Upscale                *bool                 `json:"upscale,omitempty"`
+	Scale                  *int                  `json:"scale,omitempty"`
+	ImageFormat            *ImageFormat          `json:"image_format,omitempty"`
+	ImageQuality           *int                  `json:"image_quality,omitempty"`
+	Samples                *int                  `json:"samples,omitempty"`
+	ReturnType             *ImageReturnType      `json:"return_type,omitempty"`
+	PriorNumInferenceSteps *int                  `json:"prior_num_inference_steps,omitempty"`
+	PriorGuidanceScale     *float64              `json:"prior_guidance_scale,omitempty"`
+	NumInferenceSteps      *int                  `json:"num_inference_steps,omitempty"`
+	GuidanceScale          *float64              `json:"guidance_scale,omitempty"`
+	Scheduler              *ImageDecodeScheduler `json:"scheduler,omitempty"`
+	Seed                   []int                 `json:"seed,omitempty"`
+	NSFWChecker            *bool                 `json:"nsfw_checker,omitempty"`
+}
 
 // NewParamsImageGeneration creates a new ParamsImageGeneration.
 func NewParamsImageGeneration(prompt string) ParamsImageGeneration {
 	return ParamsImageGeneration{
-		"prompt": prompt,
+		Prompt: prompt,
 	}
 }
 
 // SetNegativePrompt sets the negative prompt of ParamsImageGeneration.
 func (p ParamsImageGeneration) SetNegativePrompt(negativePrompt string) ParamsImageGeneration {
-	p["negative_prompt"] = negativePrompt
+	p.NegativePrompt = &negativePrompt
 	return p
 }
 
 // SetWidth sets the width of ParamsImageGeneration.
 func (p ParamsImageGeneration) SetWidth(width int) ParamsImageGeneration {
-	p["width"] = width
+	p.Width = &width
 	return p
 }
 
 // SetHeight sets the height of ParamsImageGeneration.
 func (p ParamsImageGeneration) SetHeight(height int) ParamsImageGeneration {
-	p["height"] = height
+	p.Height = &height
 	return p
 }
 
 // SetUpscale sets the upscale of ParamsImageGeneration.
 func (p ParamsImageGeneration) SetUpscale(upscale bool) ParamsImageGeneration {
-	p["upscale"] = upscale
+	p.Upscale = &upscale
 	return p
 }
 
 // SetScale sets the scale of ParamsImageGeneration.
 func (p ParamsImageGeneration) SetScale(scale int) ParamsImageGeneration {
-	p["scale"] = scale
+	p.Scale = &scale
 	return p
 }
 
 // SetImageFormat sets the image format of ParamsImageGeneration.
 func (p ParamsImageGeneration) SetImageFormat(format ImageFormat) ParamsImageGeneration {
-	p["image_format"] = format
+	p.ImageFormat = &format
 	return p
 }
 
 // SetImageQuality sets the image quality of ParamsImageGeneration.
 func (p ParamsImageGeneration) SetImageQuality(quality int) ParamsImageGeneration {
-	p["image_quality"] = quality
+	p.ImageQuality = &quality
 	return p
 }
 
 // SetSamples sets the samples of ParamsImageGeneration.
 func (p ParamsImageGeneration) SetSamples(samples int) ParamsImageGeneration {
-	p["samples"] = samples
+	p.Samples = &samples
 	return p
 }
 
 // SetReturnType sets the return type of ParamsImageGeneration.
 func (p ParamsImageGeneration) SetReturnType(returnType ImageReturnType) ParamsImageGeneration {
-	p["return_type"] = returnType
+	p.ReturnType = &returnType
 	return p
 }
 
 // SetPriorNumInferenceSteps sets the prior num inference steps of ParamsImageGeneration.
 func (p ParamsImageGeneration) SetPriorNumInferenceSteps(steps int) ParamsImageGeneration {
-	p["prior_num_inference_steps"] = steps
+	p.PriorNumInferenceSteps = &steps
 	return p
 }
 
 // SetPriorGuidanceScale sets the prior guidance scale of ParamsImageGeneration.
 func (p ParamsImageGeneration) SetPriorGuidanceScale(scale float64) ParamsImageGeneration {
-	p["prior_guidance_scale"] = scale
+	p.PriorGuidanceScale = &scale
 	return p
 }
 
 // SetNumInferenceSteps sets the num inference steps of ParamsImageGeneration.
 func (p ParamsImageGeneration) SetNumInferenceSteps(steps int) ParamsImageGeneration {
-	p["num_inference_steps"] = steps
+	p.NumInferenceSteps = &steps
 	return p
 }
 
 // SetGuidanceScale sets the guidance scale of ParamsImageGeneration.
 func (p ParamsImageGeneration) SetGuidanceScale(scale float64) ParamsImageGeneration {
-	p["guidance_scale"] = scale
+	p.GuidanceScale = &scale
 	return p
 }
 
 // SetScheduler sets the scheduler of ParamsImageGeneration.
 func (p ParamsImageGeneration) SetScheduler(scheduler ImageDecodeScheduler) ParamsImageGeneration {
-	p["scheduler"] = scheduler
+	p.Scheduler = &scheduler
 	return p
 }
 
 // SetSeed sets the seed of ParamsImageGeneration.
 func (p ParamsImageGeneration) SetSeed(seed []int) ParamsImageGeneration {
-	p["seed"] = seed
+	p.Seed = seed
 	return p
 }
 
 // SetNSFWChecker sets the NSFW checker of ParamsImageGeneration.
 func (p ParamsImageGeneration) SetNSFWChecker(nsfwChecker bool) ParamsImageGeneration {
-	p["nsfw_checker"] = nsfwChecker
+	p.NSFWChecker = &nsfwChecker
 	return p
 }
 
@@ -226,6 +349,8 @@ type ResponseGeneratedImages struct {
 	ID           string           `json:"id"`
 	ModelVersion string           `json:"model_version"`
 	Images       []GeneratedImage `json:"images"`
+
+	TranslatedPrompts *TranslatedPrompts `json:"translated_prompts,omitempty"` // (only when prompts were translated)
 }
 
 type GeneratedImage struct {
@@ -234,38 +359,47 @@ type GeneratedImage struct {
 	Image               string   `json:"image"`
 	NSFWContentDetected bool     `json:"nsfw_content_detected,omitempty"`
 	NSFWScore           *float64 `json:"nsfw_score,omitempty"`
+
+	httpClient *http.Client // for downloading the image (`http.DefaultClient` if nil)
 }
 
-type ParamsImageUpscale map[string]any
+// ParamsImageUpscale is the parameters for image upscale
+type ParamsImageUpscale struct {
+	Images       []string         `json:"images"`
+	Scale        *int             `json:"scale,omitempty"`
+	ImageFormat  *ImageFormat     `json:"image_format,omitempty"`
+	ImageQuality *int             `json:"image_quality,omitempty"`
+	ReturnType   *ImageReturnType `json:"return_type,omitempty"`
+}
 
-// NewParamsImageGeneration creates a new ParamsImageGeneration.
+// NewParamsImageUpscale creates a new ParamsImageUpscale.
 func NewParamsImageUpscale(base64EncodedImages []string) ParamsImageUpscale {
 	return ParamsImageUpscale{
-		"images": base64EncodedImages,
+		Images: base64EncodedImages,
 	}
 }
 
 // SetScale sets the scale of ParamsImageUpscale.
 func (p ParamsImageUpscale) SetScale(scale int) ParamsImageUpscale {
-	p["scale"] = scale
+	p.Scale = &scale
 	return p
 }
 
 // SetImageFormat sets the image format of ParamsImageUpscale.
 func (p ParamsImageUpscale) SetImageFormat(format ImageFormat) ParamsImageUpscale {
-	p["image_format"] = format
+	p.ImageFormat = &format
 	return p
 }
 
 // SetImageQuality sets the image quality of ParamsImageUpscale.
 func (p ParamsImageUpscale) SetImageQuality(quality int) ParamsImageUpscale {
-	p["image_quality"] = quality
+	p.ImageQuality = &quality
 	return p
 }
 
 // SetReturnType sets the return type of ParamsImageUpscale.
 func (p ParamsImageUpscale) SetReturnType(returnType ImageReturnType) ParamsImageUpscale {
-	p["return_type"] = returnType
+	p.ReturnType = &returnType
 	return p
 }
 
@@ -274,97 +408,115 @@ type ResponseUpscaledImages struct {
 	Images []string `json:"images"`
 }
 
-type ParamsImageVariation map[string]any
+// ParamsImageVariation is the parameters for image variation
+type ParamsImageVariation struct {
+	Image             string                `json:"image"`
+	Prompt            string                `json:"prompt"`
+	NegativePrompt    *string               `json:"negative_prompt,omitempty"`
+	Width             *int                  `json:"width,omitempty"`
+	Height            *int                  `json:"height,omitempty"`
+	Upscale           *bool                 `json:"upscale,omitempty"`
+	Scale             *int                  `json:"scale,omitempty"`
+	ImageFormat       *ImageFormat          `json:"image_format,omitempty"`
+	ImageQuality      *int                  `json:"image_quality,omitempty"`
+	Samples           *int                  `json:"samples,omitempty"`
+	ReturnType        *ImageReturnType      `json:"return_type,omitempty"`
+	NumInferenceSteps *int                  `json:"num_inference_steps,omitempty"`
+	GuidanceScale     *float64              `json:"guidance_scale,omitempty"`
+	Scheduler         *ImageDecodeScheduler `json:"scheduler,omitempty"`
+	Seed              []int                 `json:"seed,omitempty"`
+	NSFWChecker       *bool                 `json:"nsfw_checker,omitempty"`
+}
 
 // NewParamsImageVariation creates a new ParamsImageVariation.
 func NewParamsImageVariation(base64EncodedImage, prompt string) ParamsImageVariation {
 	return ParamsImageVariation{
-		"image":  base64EncodedImage,
-		"prompt": prompt,
+		Image:  base64EncodedImage,
+		Prompt: prompt,
 	}
 }
 
 // SetNegativePrompt sets the negative prompt of ParamsImageVariation.
 func (p ParamsImageVariation) SetNegativePrompt(negativePrompt string) ParamsImageVariation {
-	p["negative_prompt"] = negativePrompt
+	p.NegativePrompt = &negativePrompt
 	return p
 }
 
 // SetWidth sets the width of ParamsImageVariation.
 func (p ParamsImageVariation) SetWidth(width int) ParamsImageVariation {
-	p["width"] = width
+	p.Width = &width
 	return p
 }
 
 // SetHeight sets the height of ParamsImageVariation.
 func (p ParamsImageVariation) SetHeight(height int) ParamsImageVariation {
-	p["height"] = height
+	p.Height = &height
 	return p
 }
 
 // SetUpscale sets the upscale of ParamsImageVariation.
 func (p ParamsImageVariation) SetUpscale(upscale bool) ParamsImageVariation {
-	p["upscale"] = upscale
+	p.Upscale = &upscale
 	return p
 }
 
 // SetScale sets the scale of ParamsImageVariation.
 func (p ParamsImageVariation) SetScale(scale int) ParamsImageVariation {
-	p["scale"] = scale
+	p.Scale = &scale
 	return p
 }
 
 // SetImageFormat sets the image format of ParamsImageVariation.
 func (p ParamsImageVariation) SetImageFormat(format ImageFormat) ParamsImageVariation {
-	p["image_format"] = format
+	p.ImageFormat = &format
 	return p
 }
 
 // SetImageQuality sets the image quality of ParamsImageVariation.
 func (p ParamsImageVariation) SetImageQuality(quality int) ParamsImageVariation {
-	p["image_quality"] = quality
+	p.ImageQuality = &quality
 	return p
 }
 
 // SetSamples sets the samples of ParamsImageVariation.
 func (p ParamsImageVariation) SetSamples(samples int) ParamsImageVariation {
-	p["samples"] = samples
+	p.Samples = &samples
 	return p
 }
 
 // SetReturnType sets the return type of ParamsImageVariation.
 func (p ParamsImageVariation) SetReturnType(returnType ImageReturnType) ParamsImageVariation {
-	p["return_type"] = returnType
+	p.ReturnType = &returnType
 	return p
 }
 
 // SetNumInferenceSteps sets the num inference steps of ParamsImageVariation.
 func (p ParamsImageVariation) SetNumInferenceSteps(steps int) ParamsImageVariation {
-	p["num_inference_steps"] = steps
+	p.NumInferenceSteps = &steps
 	return p
 }
 
 // SetGuidanceScale sets the guidance scale of ParamsImageVariation.
 func (p ParamsImageVariation) SetGuidanceScale(scale float64) ParamsImageVariation {
-	p["guidance_scale"] = scale
+	p.GuidanceScale = &scale
 	return p
 }
 
 // SetScheduler sets the scheduler of ParamsImageVariation.
 func (p ParamsImageVariation) SetScheduler(scheduler ImageDecodeScheduler) ParamsImageVariation {
-	p["scheduler"] = scheduler
+	p.Scheduler = &scheduler
 	return p
 }
 
 // SetSeed sets the seed of ParamsImageVariation.
 func (p ParamsImageVariation) SetSeed(seed []int) ParamsImageVariation {
-	p["seed"] = seed
+	p.Seed = seed
 	return p
 }
 
 // SetNSFWChecker sets the NSFW checker of ParamsImageVariation.
 func (p ParamsImageVariation) SetNSFWChecker(nsfwChecker bool) ParamsImageVariation {
-	p["nsfw_checker"] = nsfwChecker
+	p.NSFWChecker = &nsfwChecker
 	return p
 }
 
@@ -373,6 +525,8 @@ type ResponseVariedImages struct {
 	ID           string           `json:"id"`
 	ModelVersion string           `json:"model_version"`
 	Images       []GeneratedImage `json:"images"`
+
+	TranslatedPrompts *TranslatedPrompts `json:"translated_prompts,omitempty"` // (only when prompts were translated)
 }
 
 // ResponseNSFWResult is the struct for nsfw checking
@@ -384,3 +538,1245 @@ type ResponseNSFWResult struct {
 		NSFWScore           float64 `json:"nsfw_score"`
 	} `json:"results"`
 }
+
+///////////////////////////////
+// Kakao Login (OAuth 2.0) structs
+//
+
+// ParamsAuthorization is the parameters for Kakao Login's authorize URL
+type ParamsAuthorization struct {
+	RedirectURI         string
+	Scopes              []string // additional consent items (eg. "profile_nickname", "talk_message")
+	State               *string
+	Prompts             []string // eg. "login", "none", "create", "select_account"
+	LoginHint           *string
+	Nonce               *string // for OpenID Connect
+	CodeChallenge       *string
+	CodeChallengeMethod *string
+}
+
+// NewParamsAuthorization creates a new ParamsAuthorization.
+func NewParamsAuthorization(redirectURI string) ParamsAuthorization {
+	return ParamsAuthorization{
+		RedirectURI: redirectURI,
+	}
+}
+
+// SetScopes sets the scopes of ParamsAuthorization.
+func (p ParamsAuthorization) SetScopes(scopes ...string) ParamsAuthorization {
+	p.Scopes = scopes
+	return p
+}
+
+// SetState sets the state of ParamsAuthorization.
+func (p ParamsAuthorization) SetState(state string) ParamsAuthorization {
+	p.State = &state
+	return p
+}
+
+// SetPrompts sets the prompts of ParamsAuthorization.
+func (p ParamsAuthorization) SetPrompts(prompts ...string) ParamsAuthorization {
+	p.Prompts = prompts
+	return p
+}
+
+// SetLoginHint sets the login hint of ParamsAuthorization.
+func (p ParamsAuthorization) SetLoginHint(loginHint string) ParamsAuthorization {
+	p.LoginHint = &loginHint
+	return p
+}
+
+// SetNonce sets the nonce of ParamsAuthorization.
+func (p ParamsAuthorization) SetNonce(nonce string) ParamsAuthorization {
+	p.Nonce = &nonce
+	return p
+}
+
+// SetPKCE sets the code challenge (and its method) of ParamsAuthorization from given PKCE.
+func (p ParamsAuthorization) SetPKCE(pkce PKCE) ParamsAuthorization {
+	p.CodeChallenge = &pkce.Challenge
+	p.CodeChallengeMethod = &pkce.Method
+	return p
+}
+
+// OAuthToken is the struct for tokens issued by Kakao Login
+type OAuthToken struct {
+	TokenType             string `json:"token_type"`
+	AccessToken           string `json:"access_token"`
+	IDToken               string `json:"id_token,omitempty"`
+	ExpiresIn             int64  `json:"expires_in"`
+	RefreshToken          string `json:"refresh_token,omitempty"`
+	RefreshTokenExpiresIn int64  `json:"refresh_token_expires_in,omitempty"`
+	Scope                 string `json:"scope,omitempty"`
+
+	// calculated from `expires_in` and `refresh_token_expires_in` when issued
+	Expiry             time.Time `json:"expiry,omitempty"`
+	RefreshTokenExpiry time.Time `json:"refresh_token_expiry,omitempty"`
+}
+
+// ResponseTokenInfo is the struct for access token information
+type ResponseTokenInfo struct {
+	ID        int64 `json:"id"`
+	ExpiresIn int64 `json:"expires_in"`
+	AppID     int64 `json:"app_id"`
+}
+
+// ResponseUserID is the struct for responses with a user's ID only (eg. logout, unlink)
+type ResponseUserID struct {
+	ID int64 `json:"id"`
+}
+
+///////////////////////////////
+// user management structs
+//
+
+// ResponseUserInfo is the struct for a user's information
+type ResponseUserInfo struct {
+	ID           int64             `json:"id"`
+	HasSignedUp  *bool             `json:"has_signed_up,omitempty"`
+	ConnectedAt  *time.Time        `json:"connected_at,omitempty"`
+	SynchedAt    *time.Time        `json:"synched_at,omitempty"`
+	Properties   map[string]string `json:"properties,omitempty"`
+	KakaoAccount *KakaoAccount     `json:"kakao_account,omitempty"`
+	ForPartner   *struct {
+		UUID *string `json:"uuid,omitempty"`
+	} `json:"for_partner,omitempty"`
+}
+
+// KakaoAccount is the struct for a user's Kakao account information
+//
+// Fields are nil if the user did not consent to them.
+type KakaoAccount struct {
+	ProfileNeedsAgreement         *bool         `json:"profile_needs_agreement,omitempty"`
+	ProfileNicknameNeedsAgreement *bool         `json:"profile_nickname_needs_agreement,omitempty"`
+	ProfileImageNeedsAgreement    *bool         `json:"profile_image_needs_agreement,omitempty"`
+	Profile                       *KakaoProfile `json:"profile,omitempty"`
+
+	NameNeedsAgreement *bool   `json:"name_needs_agreement,omitempty"`
+	Name               *string `json:"name,omitempty"`
+
+	EmailNeedsAgreement *bool   `json:"email_needs_agreement,omitempty"`
+	IsEmailValid        *bool   `json:"is_email_valid,omitempty"`
+	IsEmailVerified     *bool   `json:"is_email_verified,omitempty"`
+	Email               *string `json:"email,omitempty"`
+
+	AgeRangeNeedsAgreement  *bool   `json:"age_range_needs_agreement,omitempty"`
+	AgeRange                *string `json:"age_range,omitempty"`
+	BirthyearNeedsAgreement *bool   `json:"birthyear_needs_agreement,omitempty"`
+	Birthyear               *string `json:"birthyear,omitempty"`
+	BirthdayNeedsAgreement  *bool   `json:"birthday_needs_agreement,omitempty"`
+	Birthday                *string `json:"birthday,omitempty"`
+	BirthdayType            *string `json:"birthday_type,omitempty"`
+
+	GenderNeedsAgreement *bool   `json:"gender_needs_agreement,omitempty"`
+	Gender               *string `json:"gender,omitempty"`
+
+	PhoneNumberNeedsAgreement *bool   `json:"phone_number_needs_agreement,omitempty"`
+	PhoneNumber               *string `json:"phone_number,omitempty"`
+
+	CINeedsAgreement  *bool      `json:"ci_needs_agreement,omitempty"`
+	CI                *string    `json:"ci,omitempty"`
+	CIAuthenticatedAt *time.Time `json:"ci_authenticated_at,omitempty"`
+}
+
+// KakaoProfile is the struct for a user's profile
+type KakaoProfile struct {
+	Nickname          string `json:"nickname,omitempty"`
+	ThumbnailImageURL string `json:"thumbnail_image_url,omitempty"`
+	ProfileImageURL   string `json:"profile_image_url,omitempty"`
+	IsDefaultImage    bool   `json:"is_default_image,omitempty"`
+	IsDefaultNickname bool   `json:"is_default_nickname,omitempty"`
+}
+
+// ResponseScopes is the struct for a user's consent scopes
+type ResponseScopes struct {
+	ID     int64   `json:"id"`
+	Scopes []Scope `json:"scopes"`
+}
+
+// Scope is the struct for a consent scope
+type Scope struct {
+	ID          string `json:"id"`
+	DisplayName string `json:"display_name"`
+	Type        string `json:"type"` // "PRIVACY" or "SERVICE"
+	Using       bool   `json:"using"`
+	Agreed      bool   `json:"agreed"`
+	Revocable   *bool  `json:"revocable,omitempty"`
+	Delegated   *bool  `json:"delegated,omitempty"`
+}
+
+// ParamsUserIDs is the parameters for listing app users' IDs
+type ParamsUserIDs struct {
+	Limit  *int
+	FromID *int64
+	Order  *string // "asc" or "desc"
+}
+
+// NewParamsUserIDs creates a new ParamsUserIDs.
+func NewParamsUserIDs() ParamsUserIDs {
+	return ParamsUserIDs{}
+}
+
+// SetLimit sets the limit of ParamsUserIDs.
+func (p ParamsUserIDs) SetLimit(limit int) ParamsUserIDs {
+	p.Limit = &limit
+	return p
+}
+
+// SetFromID sets the from_id of ParamsUserIDs.
+func (p ParamsUserIDs) SetFromID(fromID int64) ParamsUserIDs {
+	p.FromID = &fromID
+	return p
+}
+
+// SetOrder sets the order of ParamsUserIDs.
+func (p ParamsUserIDs) SetOrder(order string) ParamsUserIDs {
+	p.Order = &order
+	return p
+}
+
+// ResponseUserIDs is the struct for app users' IDs
+type ResponseUserIDs struct {
+	Elements   []int64 `json:"elements"`
+	TotalCount int     `json:"total_count"`
+	AfterURL   string  `json:"after_url,omitempty"`
+	BeforeURL  string  `json:"before_url,omitempty"`
+}
+
+///////////////////////////////
+// KakaoTalk message structs
+//
+// https://developers.kakao.com/docs/latest/ko/message/message-template
+
+// MessageTemplate is a default message template (feed, list, location, commerce, text, or calendar)
+type MessageTemplate interface {
+	ObjectType() string
+	Validate() error
+}
+
+// MessageLink is the link of message contents and buttons
+type MessageLink struct {
+	WebURL                 string `json:"web_url,omitempty"`
+	MobileWebURL           string `json:"mobile_web_url,omitempty"`
+	AndroidExecutionParams string `json:"android_execution_params,omitempty"`
+	IOSExecutionParams     string `json:"ios_execution_params,omitempty"`
+}
+
+// NewMessageLink creates a new MessageLink to given URL for both web and mobile web.
+func NewMessageLink(url string) MessageLink {
+	return MessageLink{
+		WebURL:       url,
+		MobileWebURL: url,
+	}
+}
+
+// MessageContent is the content of message templates
+type MessageContent struct {
+	Title       string      `json:"title,omitempty"`
+	Description string      `json:"description,omitempty"`
+	ImageURL    string      `json:"image_url,omitempty"`
+	ImageWidth  *int        `json:"image_width,omitempty"`
+	ImageHeight *int        `json:"image_height,omitempty"`
+	Link        MessageLink `json:"link"`
+}
+
+// NewMessageContent creates a new MessageContent.
+func NewMessageContent(title, description, imageURL string, link MessageLink) MessageContent {
+	return MessageContent{
+		Title:       title,
+		Description: description,
+		ImageURL:    imageURL,
+		Link:        link,
+	}
+}
+
+// SetImageSize sets the image width and height of MessageContent.
+func (c MessageContent) SetImageSize(width, height int) MessageContent {
+	c.ImageWidth = &width
+	c.ImageHeight = &height
+	return c
+}
+
+// MessageItemContent is the item content of feed templates
+type MessageItemContent struct {
+	ProfileText        string        `json:"profile_text,omitempty"`
+	ProfileImageURL    string        `json:"profile_image_url,omitempty"`
+	TitleImageURL      string        `json:"title_image_url,omitempty"`
+	TitleImageText     string        `json:"title_image_text,omitempty"`
+	TitleImageCategory string        `json:"title_image_category,omitempty"`
+	Items              []MessageItem `json:"items,omitempty"`
+	Sum                string        `json:"sum,omitempty"`
+	SumOp              string        `json:"sum_op,omitempty"`
+}
+
+// MessageItem is an item of MessageItemContent
+type MessageItem struct {
+	Item   string `json:"item"`
+	ItemOp string `json:"item_op"`
+}
+
+// MessageSocial is the social information of message templates
+type MessageSocial struct {
+	LikeCount       *int `json:"like_count,omitempty"`
+	CommentCount    *int `json:"comment_count,omitempty"`
+	SharedCount     *int `json:"shared_count,omitempty"`
+	ViewCount       *int `json:"view_count,omitempty"`
+	SubscriberCount *int `json:"subscriber_count,omitempty"`
+}
+
+// MessageButton is a button of message templates
+type MessageButton struct {
+	Title string      `json:"title"`
+	Link  MessageLink `json:"link"`
+}
+
+// MessageCommerce is the product information of commerce templates
+type MessageCommerce struct {
+	ProductName          *string `json:"product_name,omitempty"`
+	RegularPrice         int     `json:"regular_price"`
+	DiscountPrice        *int    `json:"discount_price,omitempty"`
+	DiscountRate         *int    `json:"discount_rate,omitempty"`
+	FixedDiscountPrice   *int    `json:"fixed_discount_price,omitempty"`
+	CurrencyUnit         *string `json:"currency_unit,omitempty"`
+	CurrencyUnitPosition *int    `json:"currency_unit_position,omitempty"` // 0: after the price, 1: before the price
+}
+
+// FeedTemplate is the feed message template
+type FeedTemplate struct {
+	Content     MessageContent      `json:"content"`
+	ItemContent *MessageItemContent `json:"item_content,omitempty"`
+	Social      *MessageSocial      `json:"social,omitempty"`
+	ButtonTitle *string             `json:"button_title,omitempty"`
+	Buttons     []MessageButton     `json:"buttons,omitempty"`
+}
+
+// NewFeedTemplate creates a new FeedTemplate.
+func NewFeedTemplate(content MessageContent) FeedTemplate {
+	return FeedTemplate{
+		Content: content,
+	}
+}
+
+// SetItemContent sets the item content of FeedTemplate.
+func (t FeedTemplate) SetItemContent(itemContent MessageItemContent) FeedTemplate {
+	t.ItemContent = &itemContent
+	return t
+}
+
+// SetSocial sets the social information of FeedTemplate.
+func (t FeedTemplate) SetSocial(social MessageSocial) FeedTemplate {
+	t.Social = &social
+	return t
+}
+
+// SetButtonTitle sets the title of the default button of FeedTemplate.
+func (t FeedTemplate) SetButtonTitle(title string) FeedTemplate {
+	t.ButtonTitle = &title
+	return t
+}
+
+// AddButton adds a button to FeedTemplate.
+func (t FeedTemplate) AddButton(title string, link MessageLink) FeedTemplate {
+	t.Buttons = append(t.Buttons[:len(t.Buttons):len(t.Buttons)], MessageButton{Title: title, Link: link})
+	return t
+}
+
+// ObjectType returns "feed".
+func (t FeedTemplate) ObjectType() string { return "feed" }
+
+// ListTemplate is the list message template
+type ListTemplate struct {
+	HeaderTitle string           `json:"header_title"`
+	HeaderLink  MessageLink      `json:"header_link"`
+	Contents    []MessageContent `json:"contents"`
+	ButtonTitle *string          `json:"button_title,omitempty"`
+	Buttons     []MessageButton  `json:"buttons,omitempty"`
+}
+
+// NewListTemplate creates a new ListTemplate.
+func NewListTemplate(headerTitle string, headerLink MessageLink, contents ...MessageContent) ListTemplate {
+	return ListTemplate{
+		HeaderTitle: headerTitle,
+		HeaderLink:  headerLink,
+		Contents:    contents,
+	}
+}
+
+// SetButtonTitle sets the title of the default button of ListTemplate.
+func (t ListTemplate) SetButtonTitle(title string) ListTemplate {
+	t.ButtonTitle = &title
+	return t
+}
+
+// AddButton adds a button to ListTemplate.
+func (t ListTemplate) AddButton(title string, link MessageLink) ListTemplate {
+	t.Buttons = append(t.Buttons[:len(t.Buttons):len(t.Buttons)], MessageButton{Title: title, Link: link})
+	return t
+}
+
+// ObjectType returns "list".
+func (t ListTemplate) ObjectType() string { return "list" }
+
+// LocationTemplate is the location message template
+type LocationTemplate struct {
+	Address      string          `json:"address"`
+	AddressTitle *string         `json:"address_title,omitempty"`
+	Content      MessageContent  `json:"content"`
+	Social       *MessageSocial  `json:"social,omitempty"`
+	ButtonTitle  *string         `json:"button_title,omitempty"`
+	Buttons      []MessageButton `json:"buttons,omitempty"`
+}
+
+// NewLocationTemplate creates a new LocationTemplate.
+func NewLocationTemplate(address string, content MessageContent) LocationTemplate {
+	return LocationTemplate{
+		Address: address,
+		Content: content,
+	}
+}
+
+// SetAddressTitle sets the address title of LocationTemplate.
+func (t LocationTemplate) SetAddressTitle(title string) LocationTemplate {
+	t.AddressTitle = &title
+	return t
+}
+
+// SetSocial sets the social information of LocationTemplate.
+func (t LocationTemplate) SetSocial(social MessageSocial) LocationTemplate {
+	t.Social = &social
+	return t
+}
+
+// SetButtonTitle sets the title of the default button of LocationTemplate.
+func (t LocationTemplate) SetButtonTitle(title string) LocationTemplate {
+	t.ButtonTitle = &title
+	return t
+}
+
+// AddButton adds a button to LocationTemplate.
+func (t LocationTemplate) AddButton(title string, link MessageLink) LocationTemplate {
+	t.Buttons = append(t.Buttons[:len(t.Buttons):len(t.Buttons)], MessageButton{Title: title, Link: link})
+	return t
+}
+
+// ObjectType returns "location".
+func (t LocationTemplate) ObjectType() string { return "location" }
+
+// CommerceTemplate is the commerce message template
+type CommerceTemplate struct {
+	Content     MessageContent  `json:"content"`
+	Commerce    MessageCommerce `json:"commerce"`
+	ButtonTitle *string         `json:"button_title,omitempty"`
+	Buttons     []MessageButton `json:"buttons,omitempty"`
+}
+
+// NewCommerceTemplate creates a new CommerceTemplate.
+func NewCommerceTemplate(content MessageContent, commerce MessageCommerce) CommerceTemplate {
+	return CommerceTemplate{
+		Content:  content,
+		Commerce: commerce,
+	}
+}
+
+// SetButtonTitle sets the title of the default button of CommerceTemplate.
+func (t CommerceTemplate) SetButtonTitle(title string) CommerceTemplate {
+	t.ButtonTitle = &title
+	return t
+}
+
+// AddButton adds a button to CommerceTemplate.
+func (t CommerceTemplate) AddButton(title string, link MessageLink) CommerceTemplate {
+	t.Buttons = append(t.Buttons[:len(t.Buttons):len(t.Buttons)], MessageButton{Title: title, Link: link})
+	return t
+}
+
+// ObjectType returns "commerce".
+func (t CommerceTemplate) ObjectType() string { return "commerce" }
+
+// TextTemplate is the text message template
+type TextTemplate struct {
+	Text        string          `json:"text"`
+	Link        MessageLink     `json:"link"`
+	ButtonTitle *string         `json:"button_title,omitempty"`
+	Buttons     []MessageButton `json:"buttons,omitempty"`
+}
+
+// NewTextTemplate creates a new TextTemplate.
+func NewTextTemplate(text string, link MessageLink) TextTemplate {
+	return TextTemplate{
+		Text: text,
+		Link: link,
+	}
+}
+
+// SetButtonTitle sets the title of the default button of TextTemplate.
+func (t TextTemplate) SetButtonTitle(title string) TextTemplate {
+	t.ButtonTitle = &title
+	return t
+}
+
+// AddButton adds a button to TextTemplate.
+func (t TextTemplate) AddButton(title string, link MessageLink) TextTemplate {
+	t.Buttons = append(t.Buttons[:len(t.Buttons):len(t.Buttons)], MessageButton{Title: title, Link: link})
+	return t
+}
+
+// ObjectType returns "text".
+func (t TextTemplate) ObjectType() string { return "text" }
+
+// CalendarTemplate is the calendar message template
+type CalendarTemplate struct {
+	IDType  string          `json:"id_type"` // "event" or "calendar"
+	ID      string          `json:"id"`
+	Content MessageContent  `json:"content"`
+	Buttons []MessageButton `json:"buttons,omitempty"`
+}
+
+// NewCalendarTemplate creates a new CalendarTemplate.
+func NewCalendarTemplate(idType, id string, content MessageContent) CalendarTemplate {
+	return CalendarTemplate{
+		IDType:  idType,
+		ID:      id,
+		Content: content,
+	}
+}
+
+// AddButton adds a button to CalendarTemplate.
+func (t CalendarTemplate) AddButton(title string, link MessageLink) CalendarTemplate {
+	t.Buttons = append(t.Buttons[:len(t.Buttons):len(t.Buttons)], MessageButton{Title: title, Link: link})
+	return t
+}
+
+// ObjectType returns "calendar".
+func (t CalendarTemplate) ObjectType() string { return "calendar" }
+
+// ResponseMessageToMe is the struct for the result of sending messages to me
+type ResponseMessageToMe struct {
+	ResultCode int `json:"result_code"`
+}
+
+// ResponseMessageToFriends is the struct for the result of sending messages to friends
+type ResponseMessageToFriends struct {
+	SuccessfulReceiverUUIDs []string `json:"successful_receiver_uuids"`
+	FailureInfo             []struct {
+		Code          int      `json:"code"`
+		Msg           string   `json:"msg"`
+		ReceiverUUIDs []string `json:"receiver_uuids"`
+	} `json:"failure_info,omitempty"`
+}
+
+///////////////////////////////
+// KakaoTalk social structs
+//
+
+// ResponseTalkProfile is the struct for the current user's KakaoTalk profile
+type ResponseTalkProfile struct {
+	Nickname        string `json:"nickName"`
+	ProfileImageURL string `json:"profileImageURL"`
+	ThumbnailURL    string `json:"thumbnailURL"`
+	CountryISO      string `json:"countryISO"`
+}
+
+// ParamsFriends is the parameters for listing friends
+type ParamsFriends struct {
+	Offset      *int
+	Limit       *int
+	Order       *string // "asc" or "desc"
+	FriendOrder *string // "nickname" or "favorite"
+}
+
+// NewParamsFriends creates a new ParamsFriends.
+func NewParamsFriends() ParamsFriends {
+	return ParamsFriends{}
+}
+
+// SetOffset sets the offset of ParamsFriends.
+func (p ParamsFriends) SetOffset(offset int) ParamsFriends {
+	p.Offset = &offset
+	return p
+}
+
+// SetLimit sets the limit of ParamsFriends.
+func (p ParamsFriends) SetLimit(limit int) ParamsFriends {
+	p.Limit = &limit
+	return p
+}
+
+// SetOrder sets the order of ParamsFriends.
+func (p ParamsFriends) SetOrder(order string) ParamsFriends {
+	p.Order = &order
+	return p
+}
+
+// SetFriendOrder sets the friend order of ParamsFriends.
+func (p ParamsFriends) SetFriendOrder(friendOrder string) ParamsFriends {
+	p.FriendOrder = &friendOrder
+	return p
+}
+
+// ResponseFriends is the struct for a page of friends
+type ResponseFriends struct {
+	Elements      []Friend `json:"elements"`
+	TotalCount    int      `json:"total_count"`
+	FavoriteCount int      `json:"favorite_count"`
+	BeforeURL     string   `json:"before_url,omitempty"`
+	AfterURL      string   `json:"after_url,omitempty"`
+}
+
+// Friend is the struct for a KakaoTalk friend
+type Friend struct {
+	ID                    int64  `json:"id"`
+	UUID                  string `json:"uuid"`
+	Favorite              bool   `json:"favorite"`
+	ProfileNickname       string `json:"profile_nickname"`
+	ProfileThumbnailImage string `json:"profile_thumbnail_image"`
+}
+
+///////////////////////////////
+// Local structs
+//
+// https://developers.kakao.com/docs/latest/ko/local/dev-guide
+
+// CoordSystem is the type of coordinate systems
+type CoordSystem string
+
+// Coordinate systems
+const (
+	CoordSystemWGS84      CoordSystem = "WGS84"
+	CoordSystemWCONGNAMUL CoordSystem = "WCONGNAMUL"
+	CoordSystemCONGNAMUL  CoordSystem = "CONGNAMUL"
+	CoordSystemWTM        CoordSystem = "WTM"
+	CoordSystemTM         CoordSystem = "TM"
+	CoordSystemKTM        CoordSystem = "KTM"
+	CoordSystemUTM        CoordSystem = "UTM"
+	CoordSystemBESSEL     CoordSystem = "BESSEL"
+	CoordSystemWKTM       CoordSystem = "WKTM"
+	CoordSystemWUTM       CoordSystem = "WUTM"
+)
+
+// CategoryGroupCode is the type of place category group codes
+type CategoryGroupCode string
+
+// Category group codes
+const (
+	CategoryGroupMart              CategoryGroupCode = "MT1"
+	CategoryGroupConvenienceStore  CategoryGroupCode = "CS2"
+	CategoryGroupKindergarten      CategoryGroupCode = "PS3"
+	CategoryGroupSchool            CategoryGroupCode = "SC4"
+	CategoryGroupAcademy           CategoryGroupCode = "AC5"
+	CategoryGroupParking           CategoryGroupCode = "PK6"
+	CategoryGroupGasStation        CategoryGroupCode = "OL7"
+	CategoryGroupSubwayStation     CategoryGroupCode = "SW8"
+	CategoryGroupBank              CategoryGroupCode = "BK9"
+	CategoryGroupCulture           CategoryGroupCode = "CT1"
+	CategoryGroupAgency            CategoryGroupCode = "AG2"
+	CategoryGroupPublicInstitution CategoryGroupCode = "PO3"
+	CategoryGroupAttraction        CategoryGroupCode = "AT4"
+	CategoryGroupAccommodation     CategoryGroupCode = "AD5"
+	CategoryGroupRestaurant        CategoryGroupCode = "FD6"
+	CategoryGroupCafe              CategoryGroupCode = "CE7"
+	CategoryGroupHospital          CategoryGroupCode = "HP8"
+	CategoryGroupPharmacy          CategoryGroupCode = "PM9"
+)
+
+// Meta is the struct for pagination information of search results
+type Meta struct {
+	TotalCount    int  `json:"total_count"`
+	PageableCount int  `json:"pageable_count"`
+	IsEnd         bool `json:"is_end"`
+}
+
+// ParamsAddressSearch is the parameters for address search
+type ParamsAddressSearch struct {
+	Query       string
+	AnalyzeType *string // "similar" or "exact"
+	Page        *int
+	Size        *int
+}
+
+// NewParamsAddressSearch creates a new ParamsAddressSearch.
+func NewParamsAddressSearch(query string) ParamsAddressSearch {
+	return ParamsAddressSearch{
+		Query: query,
+	}
+}
+
+// SetAnalyzeType sets the analyze type of ParamsAddressSearch.
+func (p ParamsAddressSearch) SetAnalyzeType(analyzeType string) ParamsAddressSearch {
+	p.AnalyzeType = &analyzeType
+	return p
+}
+
+// SetPage sets the page of ParamsAddressSearch.
+func (p ParamsAddressSearch) SetPage(page int) ParamsAddressSearch {
+	p.Page = &page
+	return p
+}
+
+// SetSize sets the size of ParamsAddressSearch.
+func (p ParamsAddressSearch) SetSize(size int) ParamsAddressSearch {
+	p.Size = &size
+	return p
+}
+
+// ResponseAddresses is the struct for address search results
+type ResponseAddresses struct {
+	Meta      Meta              `json:"meta"`
+	Documents []AddressDocument `json:"documents"`
+}
+
+// AddressDocument is the struct for an address search result
+type AddressDocument struct {
+	AddressName string       `json:"address_name"`
+	AddressType string       `json:"address_type"` // "REGION", "ROAD", "REGION_ADDR", or "ROAD_ADDR"
+	X           string       `json:"x"`            // longitude
+	Y           string       `json:"y"`            // latitude
+	Address     *Address     `json:"address,omitempty"`
+	RoadAddress *RoadAddress `json:"road_address,omitempty"`
+}
+
+// Address is the struct for a land-lot address
+type Address struct {
+	AddressName       string `json:"address_name"`
+	Region1DepthName  string `json:"region_1depth_name"`
+	Region2DepthName  string `json:"region_2depth_name"`
+	Region3DepthName  string `json:"region_3depth_name"`
+	Region3DepthHName string `json:"region_3depth_h_name,omitempty"`
+	HCode             string `json:"h_code,omitempty"`
+	BCode             string `json:"b_code,omitempty"`
+	MountainYN        string `json:"mountain_yn"`
+	MainAddressNo     string `json:"main_address_no"`
+	SubAddressNo      string `json:"sub_address_no"`
+	X                 string `json:"x,omitempty"`
+	Y                 string `json:"y,omitempty"`
+}
+
+// RoadAddress is the struct for a road name address
+type RoadAddress struct {
+	AddressName      string `json:"address_name"`
+	Region1DepthName string `json:"region_1depth_name"`
+	Region2DepthName string `json:"region_2depth_name"`
+	Region3DepthName string `json:"region_3depth_name"`
+	RoadName         string `json:"road_name"`
+	UndergroundYN    string `json:"underground_yn"`
+	MainBuildingNo   string `json:"main_building_no"`
+	SubBuildingNo    string `json:"sub_building_no"`
+	BuildingName     string `json:"building_name"`
+	ZoneNo           string `json:"zone_no"`
+	X                string `json:"x,omitempty"`
+	Y                string `json:"y,omitempty"`
+}
+
+// ResponseCoordAddresses is the struct for addresses of a coordinate
+type ResponseCoordAddresses struct {
+	Meta struct {
+		TotalCount int `json:"total_count"`
+	} `json:"meta"`
+	Documents []struct {
+		Address     *Address     `json:"address,omitempty"`
+		RoadAddress *RoadAddress `json:"road_address,omitempty"`
+	} `json:"documents"`
+}
+
+// ResponseRegionCodes is the struct for region codes of a coordinate
+type ResponseRegionCodes struct {
+	Meta struct {
+		TotalCount int `json:"total_count"`
+	} `json:"meta"`
+	Documents []Region `json:"documents"`
+}
+
+// Region is the struct for an administrative ("H") or legal ("B") region
+type Region struct {
+	RegionType       string  `json:"region_type"`
+	Code             string  `json:"code"`
+	AddressName      string  `json:"address_name"`
+	Region1DepthName string  `json:"region_1depth_name"`
+	Region2DepthName string  `json:"region_2depth_name"`
+	Region3DepthName string  `json:"region_3depth_name"`
+	Region4DepthName string  `json:"region_4depth_name"`
+	X                float64 `json:"x"`
+	Y                float64 `json:"y"`
+}
+
+// ResponseTransformedCoords is the struct for transformed coordinates
+type ResponseTransformedCoords struct {
+	Meta struct {
+		TotalCount int `json:"total_count"`
+	} `json:"meta"`
+	Documents []struct {
+		X float64 `json:"x"`
+		Y float64 `json:"y"`
+	} `json:"documents"`
+}
+
+// ParamsPlaceSearch is the parameters for keyword and category place search
+type ParamsPlaceSearch struct {
+	Query             string // required for keyword search only
+	CategoryGroupCode *CategoryGroupCode
+	X                 *float64 // longitude of the center
+	Y                 *float64 // latitude of the center
+	Radius            *int     // in meters
+	Rect              *string  // "min_x,min_y,max_x,max_y"
+	Page              *int
+	Size              *int
+	Sort              *string // "accuracy" or "distance"
+}
+
+// NewParamsKeywordSearch creates a new ParamsPlaceSearch for keyword search.
+func NewParamsKeywordSearch(query string) ParamsPlaceSearch {
+	return ParamsPlaceSearch{
+		Query: query,
+	}
+}
+
+// NewParamsCategorySearch creates a new ParamsPlaceSearch for category search.
+//
+// Category search also needs either a center with radius (SetCenter, SetRadius) or a rect (SetRect).
+func NewParamsCategorySearch(code CategoryGroupCode) ParamsPlaceSearch {
+	return ParamsPlaceSearch{
+		CategoryGroupCode: &code,
+	}
+}
+
+// SetCategoryGroupCode sets the category group code of ParamsPlaceSearch.
+func (p ParamsPlaceSearch) SetCategoryGroupCode(code CategoryGroupCode) ParamsPlaceSearch {
+	p.CategoryGroupCode = &code
+	return p
+}
+
+// SetCenter sets the center (longitude and latitude) of ParamsPlaceSearch.
+func (p ParamsPlaceSearch) SetCenter(x, y float64) ParamsPlaceSearch {
+	p.X = &x
+	p.Y = &y
+	return p
+}
+
+// SetRadius sets the radius (in meters) of ParamsPlaceSearch.
+func (p ParamsPlaceSearch) SetRadius(radius int) ParamsPlaceSearch {
+	p.Radius = &radius
+	return p
+}
+
+// SetRect sets the rect of ParamsPlaceSearch.
+func (p ParamsPlaceSearch) SetRect(minX, minY, maxX, maxY float64) ParamsPlaceSearch {
+	rect := strings.Join([]string{formatFloat(minX), formatFloat(minY), formatFloat(maxX), formatFloat(maxY)}, ",")
+	p.Rect = &rect
+	return p
+}
+
+// SetPage sets the page of ParamsPlaceSearch.
+func (p ParamsPlaceSearch) SetPage(page int) ParamsPlaceSearch {
+	p.Page = &page
+	return p
+}
+
+// SetSize sets the size of ParamsPlaceSearch.
+func (p ParamsPlaceSearch) SetSize(size int) ParamsPlaceSearch {
+	p.Size = &size
+	return p
+}
+
+// SetSort sets the sort of ParamsPlaceSearch.
+func (p ParamsPlaceSearch) SetSort(sort string) ParamsPlaceSearch {
+	p.Sort = &sort
+	return p
+}
+
+// ResponsePlaces is the struct for place search results
+type ResponsePlaces struct {
+	Meta struct {
+		Meta
+		SameName *struct {
+			Region         []string `json:"region"`
+			Keyword        string   `json:"keyword"`
+			SelectedRegion string   `json:"selected_region"`
+		} `json:"same_name,omitempty"` // (keyword search only)
+	} `json:"meta"`
+	Documents []Place `json:"documents"`
+}
+
+// Place is the struct for a place search result
+type Place struct {
+	ID                string `json:"id"`
+	PlaceName         string `json:"place_name"`
+	CategoryName      string `json:"category_name"`
+	CategoryGroupCode string `json:"category_group_code"`
+	CategoryGroupName string `json:"category_group_name"`
+	Phone             string `json:"phone"`
+	AddressName       string `json:"address_name"`
+	RoadAddressName   string `json:"road_address_name"`
+	X                 string `json:"x"` // longitude
+	Y                 string `json:"y"` // latitude
+	PlaceURL          string `json:"place_url"`
+	Distance          string `json:"distance,omitempty"` // in meters (only when the center is given)
+}
+
+///////////////////////////////
+// Daum search structs
+//
+// https://developers.kakao.com/docs/latest/ko/daum-search/dev-guide
+
+// ParamsSearch is the parameters for Daum searches
+type ParamsSearch struct {
+	Query  string
+	Sort   *string // "accuracy" or "recency" ("accuracy" or "latest" for book search)
+	Page   *int
+	Size   *int
+	Target *string // (book search only) "title", "isbn", "publisher", or "person"
+}
+
+// NewParamsSearch creates a new ParamsSearch.
+func NewParamsSearch(query string) ParamsSearch {
+	return ParamsSearch{
+		Query: query,
+	}
+}
+
+// SetSort sets the sort of ParamsSearch.
+func (p ParamsSearch) SetSort(sort string) ParamsSearch {
+	p.Sort = &sort
+	return p
+}
+
+// SetPage sets the page of ParamsSearch.
+func (p ParamsSearch) SetPage(page int) ParamsSearch {
+	p.Page = &page
+	return p
+}
+
+// SetSize sets the size of ParamsSearch.
+func (p ParamsSearch) SetSize(size int) ParamsSearch {
+	p.Size = &size
+	return p
+}
+
+// SetTarget sets the target of ParamsSearch (for book search only).
+func (p ParamsSearch) SetTarget(target string) ParamsSearch {
+	p.Target = &target
+	return p
+}
+
+// Datetime is the time of search results, which is zero if not given
+type Datetime struct {
+	time.Time
+}
+
+// UnmarshalJSON decodes ISO 8601 strings, tolerating empty ones.
+func (d *Datetime) UnmarshalJSON(b []byte) error {
+	if s := string(b); s == `""` || s == "null" {
+		return nil
+	}
+
+	return d.Time.UnmarshalJSON(b)
+}
+
+// ResponseSearch is the struct for Daum search results
+type ResponseSearch[T any] struct {
+	Meta      Meta `json:"meta"`
+	Documents []T  `json:"documents"`
+}
+
+// WebDocument is the struct for a web search result
+//
+// Matched keywords in the title and contents are highlighted with `<b>` tags (see StripHighlight).
+type WebDocument struct {
+	Title    string   `json:"title"`
+	Contents string   `json:"contents"`
+	URL      string   `json:"url"`
+	Datetime Datetime `json:"datetime"`
+}
+
+// VideoDocument is the struct for a video search result
+type VideoDocument struct {
+	Title     string   `json:"title"`
+	URL       string   `json:"url"`
+	Datetime  Datetime `json:"datetime"`
+	PlayTime  int      `json:"play_time"` // in seconds
+	Thumbnail string   `json:"thumbnail"`
+	Author    string   `json:"author"`
+}
+
+// ImageDocument is the struct for an image search result
+type ImageDocument struct {
+	Collection      string   `json:"collection"`
+	ThumbnailURL    string   `json:"thumbnail_url"`
+	ImageURL        string   `json:"image_url"`
+	Width           int      `json:"width"`
+	Height          int      `json:"height"`
+	DisplaySitename string   `json:"display_sitename"`
+	DocURL          string   `json:"doc_url"`
+	Datetime        Datetime `json:"datetime"`
+}
+
+// BlogDocument is the struct for a blog search result
+type BlogDocument struct {
+	Title     string   `json:"title"`
+	Contents  string   `json:"contents"`
+	URL       string   `json:"url"`
+	Blogname  string   `json:"blogname"`
+	Thumbnail string   `json:"thumbnail"`
+	Datetime  Datetime `json:"datetime"`
+}
+
+// BookDocument is the struct for a book search result
+type BookDocument struct {
+	Title       string   `json:"title"`
+	Contents    string   `json:"contents"`
+	URL         string   `json:"url"`
+	ISBN        string   `json:"isbn"` // ISBN10 and ISBN13 separated with a space
+	Datetime    Datetime `json:"datetime"`
+	Authors     []string `json:"authors"`
+	Publisher   string   `json:"publisher"`
+	Translators []string `json:"translators"`
+	Price       int      `json:"price"`
+	SalePrice   int      `json:"sale_price"`
+	Thumbnail   string   `json:"thumbnail"`
+	Status      string   `json:"status"`
+}
+
+// CafeDocument is the struct for a cafe search result
+type CafeDocument struct {
+	Title     string   `json:"title"`
+	Contents  string   `json:"contents"`
+	URL       string   `json:"url"`
+	Cafename  string   `json:"cafename"`
+	Thumbnail string   `json:"thumbnail"`
+	Datetime  Datetime `json:"datetime"`
+}
+
+///////////////////////////////
+// translation structs
+//
+// https://developers.kakao.com/docs/latest/ko/translate/dev-guide
+
+// LanguageCode is the type of language codes for translation
+type LanguageCode string
+
+// Language codes
+const (
+	LanguageKorean     LanguageCode = "kr"
+	LanguageEnglish    LanguageCode = "en"
+	LanguageJapanese   LanguageCode = "jp"
+	LanguageChinese    LanguageCode = "cn"
+	LanguageVietnamese LanguageCode = "vi"
+	LanguageIndonesian LanguageCode = "id"
+	LanguageArabic     LanguageCode = "ar"
+	LanguageBengali    LanguageCode = "bn"
+	LanguageGerman     LanguageCode = "de"
+	LanguageSpanish    LanguageCode = "es"
+	LanguageFrench     LanguageCode = "fr"
+	LanguageHindi      LanguageCode = "hi"
+	LanguageItalian    LanguageCode = "it"
+	LanguageMalay      LanguageCode = "ms"
+	LanguageDutch      LanguageCode = "nl"
+	LanguagePortuguese LanguageCode = "pt"
+	LanguageRussian    LanguageCode = "ru"
+	LanguageThai       LanguageCode = "th"
+	LanguageTurkish    LanguageCode = "tr"
+)
+
+// ResponseTranslation is the struct for translated text
+//
+// Each element is a line of the translated text, split into sentences.
+type ResponseTranslation struct {
+	TranslatedText [][]string `json:"translated_text"`
+}
+
+// ResponseLanguageDetection is the struct for detected languages
+type ResponseLanguageDetection struct {
+	LanguageInfo []struct {
+		Code       LanguageCode `json:"code"`
+		Name       string       `json:"name"`
+		Confidence float64      `json:"confidence"`
+	} `json:"language_info"`
+}
+
+///////////////////////////////
+// vision structs
+//
+// https://developers.kakao.com/docs/latest/ko/vision/dev-guide
+
+// ImageSource is the image to be analyzed with vision APIs,
+// which is either a URL of the image or its content
+type ImageSource struct {
+	url    string
+	bytes  []byte
+	reader io.Reader
+}
+
+// NewImageSourceFromURL creates a new ImageSource with given image URL
+func NewImageSourceFromURL(imageURL string) ImageSource {
+	return ImageSource{url: imageURL}
+}
+
+// NewImageSourceFromBytes creates a new ImageSource with given image bytes
+//
+// It can be reused, and shared among concurrent requests.
+func NewImageSourceFromBytes(b []byte) ImageSource {
+	return ImageSource{bytes: b}
+}
+
+// NewImageSourceFromReader creates a new ImageSource with given reader of an image
+//
+// It is single-use: the reader is consumed by the request, so it should not be reused
+// or shared among concurrent requests. Requests with it cannot be retried if the reader is not seekable.
+func NewImageSourceFromReader(reader io.Reader) ImageSource {
+	return ImageSource{reader: reader}
+}
+
+// returns a new file param of the image content for a request (nil if it is a URL)
+func (s ImageSource) fileParam() *fileParam {
+	if s.bytes != nil {
+		return newFileParamFromBytes(s.bytes) // (with its own reader for each request)
+	} else if s.reader != nil {
+		return newFileParamFromReader(s.reader)
+	}
+
+	return nil
+}
+
+// BoundingBox is a box in normalized coordinates,
+// where (0, 0) is the top-left and (1, 1) is the bottom-right corner of the image
+type BoundingBox struct {
+	X      float64 `json:"x"`
+	Y      float64 `json:"y"`
+	Width  float64 `json:"width"`
+	Height float64 `json:"height"`
+}
+
+// Rect returns the box in pixels of an image with given width and height.
+func (b BoundingBox) Rect(width, height int) image.Rectangle {
+	return image.Rect(
+		int(math.Round(b.X*float64(width))),
+		int(math.Round(b.Y*float64(height))),
+		int(math.Round((b.X+b.Width)*float64(width))),
+		int(math.Round((b.Y+b.Height)*float64(height))),
+	)
+}
+
+// ResponseFaceDetection is the struct for detected faces
+type ResponseFaceDetection struct {
+	RID    string `json:"rid"`
+	Result struct {
+		Width  int    `json:"width"`
+		Height int    `json:"height"`
+		Faces  []Face `json:"faces"`
+	} `json:"result"`
+}
+
+// Face is the struct for a detected face
+type Face struct {
+	X     float64 `json:"x"`
+	Y     float64 `json:"y"`
+	W     float64 `json:"w"`
+	H     float64 `json:"h"`
+	Score float64 `json:"score"`
+	Pitch float64 `json:"pitch"`
+	Yaw   float64 `json:"yaw"`
+	Roll  float64 `json:"roll"`
+
+	FacialAttributes struct {
+		Gender struct {
+			Male   float64 `json:"male"`
+			Female float64 `json:"female"`
+		} `json:"gender"`
+		Age float64 `json:"age"`
+	} `json:"facial_attributes"`
+	FacialPoints struct {
+		Jaw          [][2]float64 `json:"jaw"`
+		RightEyebrow [][2]float64 `json:"right_eyebrow"`
+		LeftEyebrow  [][2]float64 `json:"left_eyebrow"`
+		Nose         [][2]float64 `json:"nose"`
+		RightEye     [][2]float64 `json:"right_eye"`
+		LeftEye      [][2]float64 `json:"left_eye"`
+		Lips         [][2]float64 `json:"lips"`
+	} `json:"facial_points"` // (normalized coordinates)
+}
+
+// Box returns the bounding box of the face.
+func (f Face) Box() BoundingBox {
+	return BoundingBox{X: f.X, Y: f.Y, Width: f.W, Height: f.H}
+}
+
+// ResponseProductDetection is the struct for detected products
+type ResponseProductDetection struct {
+	RID    string `json:"rid"`
+	Result struct {
+		Width   int       `json:"width"`
+		Height  int       `json:"height"`
+		Objects []Product `json:"objects"`
+	} `json:"result"`
+}
+
+// Product is the struct for a detected product
+type Product struct {
+	X1    float64 `json:"x1"`
+	Y1    float64 `json:"y1"`
+	X2    float64 `json:"x2"`
+	Y2    float64 `json:"y2"`
+	Class string  `json:"class"`
+}
+
+// Box returns the bounding box of the product.
+func (p Product) Box() BoundingBox {
+	return BoundingBox{X: p.X1, Y: p.Y1, Width: p.X2 - p.X1, Height: p.Y2 - p.Y1}
+}
+
+// ResponseMultitag is the struct for generated tags of an image
+type ResponseMultitag struct {
+	RID    string `json:"rid"`
+	Result struct {
+		Label           []string `json:"label"`
+		LabelCategory   []string `json:"label_category"`
+		LabelKr         []string `json:"label_kr"`
+		LabelKrCategory []string `json:"label_kr_category"`
+	} `json:"result"`
+}
+
+// ResponseThumbnailDetection is the struct for a detected thumbnail area
+type ResponseThumbnailDetection struct {
+	RID    string `json:"rid"`
+	Result struct {
+		Width     int `json:"width"`
+		Height    int `json:"height"`
+		Thumbnail struct {
+			X      int `json:"x"`
+			Y      int `json:"y"`
+			Width  int `json:"width"`
+			Height int `json:"height"`
+		} `json:"thumbnail"` // (in pixels)
+	} `json:"result"`
+}
+
+// Box returns the bounding box of the thumbnail area in normalized coordinates.
+func (r ResponseThumbnailDetection) Box() BoundingBox {
+	if r.Result.Width <= 0 || r.Result.Height <= 0 {
+		return BoundingBox{}
+	}
+
+	width, height := float64(r.Result.Width), float64(r.Result.Height)
+	thumbnail := r.Result.Thumbnail
+
+	return BoundingBox{
+		X:      float64(thumbnail.X) / width,
+		Y:      float64(thumbnail.Y) / height,
+		Width:  float64(thumbnail.Width) / width,
+		Height: float64(thumbnail.Height) / height,
+	}
+}
+
+// ResponseThumbnailCrop is the struct for a cropped thumbnail
+type ResponseThumbnailCrop struct {
+	ThumbnailImageURL string `json:"thumbnail_image_url"`
+}
+
+// ResponseAdultDetection is the struct for the result of adult content detection
+type ResponseAdultDetection struct {
+	RID    string `json:"rid"`
+	Result struct {
+		Normal float64 `json:"normal"`
+		Soft   float64 `json:"soft"`
+		Adult  float64 `json:"adult"`
+	} `json:"result"`
+}
@@ -1,7 +1,17 @@
 package kakaoapi
 
 import (
+	"bytes"
+	"context"
 	"encoding/base64"
+	"fmt"
+	"image"
+	_ "image/jpeg" // for decoding jpeg images
+	_ "image/png"  // for decoding png images
+	"io"
+	"net/http"
+	"os"
+	"strings"
 )
 
 // EncodeBase64 encodes given bytes array into a base64-encoded string.
@@ -11,7 +21,101 @@ func EncodeBase64(bytes []byte) (encoded string) {
 
 // DecodeBase64 decodes given base64-encoded string into a bytes array.
 func DecodeBase64(encoded string) (decoded []byte, err error) {
-	_, err = base64.StdEncoding.Decode(decoded, []byte(encoded))
+	return base64.StdEncoding.DecodeString(encoded)
+}
+
+// EncodeImageFile reads the image file at given path and encodes it into a base64-encoded string,
+// along with its format sniffed from the content.
+func EncodeImageFile(path string) (encoded string, format ImageFormat, err error) {
+	var bytes []byte
+	if bytes, err = os.ReadFile(path); err == nil {
+		if format, err = imageFormatOf(bytes); err == nil {
+			return EncodeBase64(bytes), format, nil
+		}
+	}
+
+	return "", "", err
+}
+
+// returns the image format of given bytes, or an error if it is not supported by Karlo
+func imageFormatOf(bytes []byte) (ImageFormat, error) {
+	switch format := ImageFormat(getExtension(bytes)); format {
+	case ImageFormatJPEG, ImageFormatPNG, ImageFormatWEBP:
+		return format, nil
+	default:
+		return "", fmt.Errorf("unsupported image format: '%s'", http.DetectContentType(bytes))
+	}
+}
+
+// Bytes returns the bytes of the generated image,
+// downloading it if it was returned as an URL, or decoding it if it was returned as a base64-encoded string.
+func (i GeneratedImage) Bytes(ctx context.Context) ([]byte, error) {
+	return imageBytes(ctx, i.httpClient, i.Image)
+}
+
+// SaveTo saves the generated image to given path.
+func (i GeneratedImage) SaveTo(ctx context.Context, path string) (err error) {
+	var bytes []byte
+	if bytes, err = i.Bytes(ctx); err == nil {
+		err = os.WriteFile(path, bytes, 0644)
+	}
+
+	return err
+}
+
+// DecodeImage returns the generated image as an `image.Image`.
+//
+// NOTE: only jpeg and png formats are supported.
+func (i GeneratedImage) DecodeImage(ctx context.Context) (img image.Image, err error) {
+	var b []byte
+	if b, err = i.Bytes(ctx); err == nil {
+		img, _, err = image.Decode(bytes.NewReader(b))
+	}
+
+	return img, err
+}
+
+// ImageBytes returns the bytes of given image (eg. an upscaled one),
+// downloading it with the client's HTTP client if it is an URL, or decoding it if it is a base64-encoded string.
+func (c *Client) ImageBytes(ctx context.Context, urlOrBase64 string) ([]byte, error) {
+	return imageBytes(ctx, c.httpClient, urlOrBase64)
+}
+
+// sets the client's HTTP client to given images for downloading them
+func (c *Client) bindImages(images []GeneratedImage) {
+	for i := range images {
+		images[i].httpClient = c.httpClient
+	}
+}
+
+// returns the bytes of given image, which is either an URL or a base64-encoded string
+func imageBytes(ctx context.Context, httpClient *http.Client, urlOrBase64 string) ([]byte, error) {
+	if strings.HasPrefix(urlOrBase64, "https://") || strings.HasPrefix(urlOrBase64, "http://") {
+		return downloadBytes(ctx, httpClient, urlOrBase64)
+	}
+
+	return DecodeBase64(urlOrBase64)
+}
+
+// downloads bytes from given URL with the HTTP client (`http.DefaultClient` if nil)
+func downloadBytes(ctx context.Context, httpClient *http.Client, url string) (bytes []byte, err error) {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	var req *http.Request
+	if req, err = http.NewRequestWithContext(ctx, "GET", url, nil); err == nil {
+		var resp *http.Response
+		if resp, err = httpClient.Do(req); err == nil {
+			defer resp.Body.Close()
+
+			if resp.StatusCode != http.StatusOK {
+				return nil, fmt.Errorf("HTTP status %d while downloading %s", resp.StatusCode, url)
+			}
+
+			return io.ReadAll(resp.Body)
+		}
+	}
 
-	return decoded, err
+	return nil, err
 }
@@ -0,0 +1,145 @@
+package kakaoapi
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+)
+
+const sampleImagePath = "./sample/image.jpg"
+
+func TestBase64(t *testing.T) {
+	original := []byte("kakao-api-go")
+
+	if decoded, err := DecodeBase64(EncodeBase64(original)); err != nil {
+		t.Errorf("failed to decode base64: %s", err)
+	} else if !bytes.Equal(decoded, original) {
+		t.Errorf("decoded bytes are different from the original: %s", string(decoded))
+	}
+
+	if _, err := DecodeBase64("not a base64 string!"); err == nil {
+		t.Errorf("should fail to decode invalid base64 string")
+	}
+}
+
+func TestEncodeImageFile(t *testing.T) {
+	original, err := os.ReadFile(sampleImagePath)
+	if err != nil {
+		t.Fatalf("failed to read sample image file: %s", err)
+	}
+
+	encoded, format, err := EncodeImageFile(sampleImagePath)
+	if err != nil {
+		t.Fatalf("failed to encode image file: %s", err)
+	}
+	if format != ImageFormatJPEG {
+		t.Errorf("format should be jpeg, but was '%s'", format)
+	}
+	if decoded, _ := DecodeBase64(encoded); !bytes.Equal(decoded, original) {
+		t.Errorf("decoded bytes are different from the original file")
+	}
+
+	// not an image file
+	notImagePath := filepath.Join(t.TempDir(), "not-image.txt")
+	os.WriteFile(notImagePath, []byte("not an image"), 0644)
+	if _, _, err := EncodeImageFile(notImagePath); err == nil {
+		t.Errorf("should fail to encode non-image file")
+	}
+}
+
+func TestGeneratedImage(t *testing.T) {
+	original, err := os.ReadFile(sampleImagePath)
+	if err != nil {
+		t.Fatalf("failed to read sample image file: %s", err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/image.jpg" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write(original)
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+
+	for name, image := range map[string]GeneratedImage{
+		"base64": {Image: EncodeBase64(original)},
+		"url":    {Image: server.URL + "/image.jpg"},
+	} {
+		if b, err := image.Bytes(ctx); err != nil {
+			t.Errorf("[%s] failed to get bytes: %s", name, err)
+		} else if !bytes.Equal(b, original) {
+			t.Errorf("[%s] bytes are different from the original", name)
+		}
+
+		path := filepath.Join(t.TempDir(), name+".jpg")
+		if err := image.SaveTo(ctx, path); err != nil {
+			t.Errorf("[%s] failed to save image: %s", name, err)
+		} else if saved, _ := os.ReadFile(path); !bytes.Equal(saved, original) {
+			t.Errorf("[%s] saved file is different from the original", name)
+		}
+
+		if img, err := image.DecodeImage(ctx); err != nil {
+			t.Errorf("[%s] failed to decode image: %s", name, err)
+		} else if img.Bounds().Dx() <= 0 || img.Bounds().Dy() <= 0 {
+			t.Errorf("[%s] decoded image is empty: %v", name, img.Bounds())
+		}
+	}
+
+	if _, err := (GeneratedImage{Image: server.URL + "/not-found.jpg"}).Bytes(ctx); err == nil {
+		t.Errorf("should fail to download non-existent image")
+	}
+}
+
+// round tripper which counts requests
+type countingTransport struct {
+	requests int32
+}
+
+func (t *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	atomic.AddInt32(&t.requests, 1)
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestImagesDownloadedWithClient(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/image.jpg":
+			w.Write([]byte("image bytes"))
+		default:
+			w.Write([]byte(`{"id":"generated","images":[{"id":"image","image":"` + server.URL + `/image.jpg"}]}`))
+		}
+	}))
+	defer server.Close()
+
+	transport := &countingTransport{}
+	client := NewClient("test-api-key",
+		WithBaseURL(ServiceKarlo, server.URL),
+		WithHTTPClient(&http.Client{Transport: transport}),
+	)
+	ctx := context.Background()
+
+	generated, err := client.GenerateImages(NewParamsImageGeneration("a cat").SetReturnType(ImageReturnURL))
+	if err != nil {
+		t.Fatalf("failed to generate images: %s", err)
+	}
+	if b, err := generated.Images[0].Bytes(ctx); err != nil || string(b) != "image bytes" {
+		t.Errorf("failed to download generated image: %s, %v", b, err)
+	}
+	if b, err := client.ImageBytes(ctx, server.URL+"/image.jpg"); err != nil || string(b) != "image bytes" {
+		t.Errorf("failed to download image: %s, %v", b, err)
+	}
+
+	// (1 for generation, 2 for downloads)
+	if n := atomic.LoadInt32(&transport.requests); n != 3 {
+		t.Errorf("expected 3 requests with the client's transport, got %d", n)
+	}
+}
@@ -0,0 +1,48 @@
+package kakaoapi
+
+import (
+	"errors"
+	"fmt"
+	"unicode/utf8"
+)
+
+// validator collects validation errors of parameters
+type validator struct {
+	errs []error
+}
+
+// adds a validation error of given field
+func (v *validator) fail(field, format string, args ...any) {
+	v.errs = append(v.errs, &ValidationError{
+		Field:   field,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+// returns all collected validation errors as one
+func (v *validator) err() error {
+	return errors.Join(v.errs...)
+}
+
+// checks if given string is not empty and not longer than `max` characters
+func (v *validator) checkString(field, value string, required bool, max int) {
+	if required && len(value) <= 0 {
+		v.fail(field, "required")
+	} else if length := utf8.RuneCountInString(value); max > 0 && length > max {
+		v.fail(field, "length %d exceeds %d", length, max)
+	}
+}
+
+// checks if given int value (if set) is in the range of [min, max]
+func (v *validator) checkIntRange(field string, value *int, min, max int) {
+	if value != nil && (*value < min || *value > max) {
+		v.fail(field, "%d is out of range [%d, %d]", *value, min, max)
+	}
+}
+
+// checks if given float value (if set) is in the range of [min, max]
+func (v *validator) checkFloatRange(field string, value *float64, min, max float64) {
+	if value != nil && (*value < min || *value > max) {
+		v.fail(field, "%g is out of range [%g, %g]", *value, min, max)
+	}
+}
@@ -0,0 +1,105 @@
+package kakaoapi
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestValidateImageGeneration(t *testing.T) {
+	valid := NewParamsImageGeneration("A cat with white fur").
+		SetWidth(1024).
+		SetHeight(768).
+		SetImageQuality(70).
+		SetSamples(2).
+		SetSeed([]int{1, 4294967295}).
+		SetScheduler(ImageDecodeSchedulerDDIM)
+	if err := valid.Validate(); err != nil {
+		t.Errorf("should be valid: %s", err)
+	}
+
+	for name, params := range map[string]ParamsImageGeneration{
+		"prompt":         NewParamsImageGeneration(""),
+		"width":          NewParamsImageGeneration("test").SetWidth(1000 + 4),
+		"height":         NewParamsImageGeneration("test").SetHeight(128),
+		"image_quality":  NewParamsImageGeneration("test").SetImageQuality(101),
+		"samples":        NewParamsImageGeneration("test").SetSamples(9),
+		"scheduler":      NewParamsImageGeneration("test").SetScheduler("unknown"),
+		"guidance_scale": NewParamsImageGeneration("test").SetGuidanceScale(0.5),
+		"seed":           NewParamsImageGeneration("test").SetSamples(2).SetSeed([]int{1}),
+	} {
+		err := params.Validate()
+
+		var validationErr *ValidationError
+		if !errors.As(err, &validationErr) {
+			t.Errorf("should be invalid for '%s': %v", name, err)
+		} else if validationErr.Field != name {
+			t.Errorf("invalid field should be '%s', but was '%s'", name, validationErr.Field)
+		}
+	}
+}
+
+func TestValidateBeforeSending(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("invalid request should not be sent: %s", r.URL.Path)
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(ServiceKarlo, server.URL))
+
+	if _, err := client.GenerateImages(NewParamsImageGeneration("test").SetSamples(10)); err == nil {
+		t.Errorf("should fail with invalid samples")
+	}
+	if _, err := client.VaryImage(NewParamsImageVariation("", "test")); err == nil {
+		t.Errorf("should fail with empty image")
+	}
+	if _, err := client.UpscaleImages(NewParamsImageUpscale(nil).SetScale(3)); err == nil {
+		t.Errorf("should fail with empty images and invalid scale")
+	}
+}
+
+func TestValidateTextGeneration(t *testing.T) {
+	if err := NewParamsTextGeneration("오늘 아침 하늘은 곧 비가 올 것 같아서", 120).SetTemp(0.7).SetTopP(0.9).SetN(2).Validate(); err != nil {
+		t.Errorf("should be valid: %s", err)
+	}
+
+	for name, params := range map[string]ParamsTextGeneration{
+		"prompt":      NewParamsTextGeneration("", 120),
+		"max_tokens":  NewParamsTextGeneration("test", 0),
+		"temperature": NewParamsTextGeneration("test", 120).SetTemp(1.5),
+		"top_p":       NewParamsTextGeneration("test", 120).SetTopP(0),
+		"n":           NewParamsTextGeneration("test", 120).SetN(17),
+	} {
+		err := params.Validate()
+
+		var validationErr *ValidationError
+		if !errors.As(err, &validationErr) {
+			t.Errorf("should be invalid for '%s': %v", name, err)
+		} else if validationErr.Field != name {
+			t.Errorf("invalid field should be '%s', but was '%s'", name, validationErr.Field)
+		}
+	}
+
+	// prompt plus max_tokens exceeding the context
+	if err := NewParamsTextGeneration(strings.Repeat("가", 2000), 100).Validate(); err == nil {
+		t.Errorf("should be invalid for exceeding tokens")
+	}
+}
+
+func TestEstimateTokens(t *testing.T) {
+	for text, expected := range map[string]int{
+		"":                  0,
+		"오늘 아침":             4,
+		"hello world":       4,
+		"2023년 5월":          5,
+		"A cat, 고양이!":       7,
+		"   \n\t":           0,
+		"supercalifragilis": 5,
+	} {
+		if estimated := EstimateTokens(text); estimated != expected {
+			t.Errorf("estimated tokens of '%s' should be %d, but was %d", text, expected, estimated)
+		}
+	}
+}